@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/prime-run/go-typer/ui"
+	"github.com/spf13/cobra"
+)
+
+var challengeCmd = &cobra.Command{
+	Use:   "challenge",
+	Short: "Play typing challenges with pass criteria",
+	Long: `Play typing challenges: a YAML file sets the passage, game mode, rules and
+the WPM and accuracy needed to pass, so training packs can be kept in a
+repository.`,
+}
+
+var challengeRunCmd = &cobra.Command{
+	Use:   "run <file.yml>",
+	Short: "Play a challenge file",
+	Long: `Play a challenge file. The end screen shows whether the attempt passed, retry
+as often as you like. Exits with status 0 if the last finished attempt passed
+and 1 otherwise, so challenges can gate scripts.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		challenge, err := ui.LoadChallenge(args[0])
+		if err != nil {
+			cmd.Println(err)
+			os.Exit(1)
+		}
+
+		ui.ApplySettings()
+		passed, err := ui.RunChallenge(challenge)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if !passed {
+			cmd.Printf("Challenge %s not passed (%s)\n", challenge.Name, challenge.Criteria())
+			os.Exit(1)
+		}
+		cmd.Printf("Challenge %s passed\n", challenge.Name)
+	},
+}
+
+func init() {
+	challengeCmd.AddCommand(challengeRunCmd)
+	rootCmd.AddCommand(challengeCmd)
+}
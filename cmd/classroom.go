@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prime-run/go-typer/race"
+	"github.com/prime-run/go-typer/ui"
+	"github.com/spf13/cobra"
+)
+
+const defaultClassroomAddr = ":7881"
+
+var (
+	classText      string
+	classFile      string
+	classMode      string
+	classTimeLimit int
+	classErrors    string
+	classBackspace string
+	classCSV       string
+	studentName    string
+)
+
+var classroomCmd = &cobra.Command{
+	Use:   "classroom",
+	Short: "Run a typing workshop",
+	Long: `Run a typing workshop: the instructor hosts a classroom and hands out a passage
+with its rules, students join and their results are collected live.`,
+}
+
+var classroomHostCmd = &cobra.Command{
+	Use:   "host [address]",
+	Short: "Host a classroom as the instructor",
+	Long: `Host a classroom. Students join with "go-typer classroom join <host:port>".
+Press s to hand out the passage and start a round, every student types it under
+the same rules. Results show up live and are exported to a CSV file with e and
+when you quit.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := defaultClassroomAddr
+		if len(args) > 0 {
+			addr = args[0]
+		}
+
+		if classFile != "" {
+			data, err := os.ReadFile(classFile)
+			if err != nil {
+				cmd.Printf("Could not read text file %s: %v\n", classFile, err)
+				os.Exit(1)
+			}
+			classText = string(data)
+		}
+
+		if classMode != "" {
+			if !slices.Contains(ui.GameModeNames(), classMode) {
+				cmd.Printf("Unknown game mode '%s'. Available modes: %s\n", classMode, strings.Join(ui.GameModeNames(), ", "))
+				os.Exit(1)
+			}
+			ui.CurrentSettings.GameMode = classMode
+		}
+
+		if classErrors != "" && string(ui.ParseErrorPolicy(classErrors)) != strings.ToLower(classErrors) {
+			cmd.Printf("Unknown error policy '%s'. Available policies: free, stop_on_letter, stop_on_word\n", classErrors)
+			os.Exit(1)
+		}
+		if classBackspace != "" && string(ui.ParseBackspacePolicy(classBackspace)) != strings.ToLower(classBackspace) {
+			cmd.Printf("Unknown backspace policy '%s'. Available policies: free, word, off\n", classBackspace)
+			os.Exit(1)
+		}
+
+		classroom := race.NewClassroom(race.Assignment{
+			Text:            ui.GameText(classText),
+			TimeLimit:       classTimeLimit,
+			ErrorPolicy:     strings.ToLower(classErrors),
+			BackspacePolicy: strings.ToLower(classBackspace),
+		})
+		if err := classroom.Listen(addr); err != nil {
+			cmd.Println(err)
+			os.Exit(1)
+		}
+
+		if classCSV == "" {
+			classCSV = fmt.Sprintf("classroom-%s.csv", time.Now().Format("2006-01-02-1504"))
+		}
+
+		if err := ui.RunInstructor(classroom, classCSV); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var classroomJoinCmd = &cobra.Command{
+	Use:   "join <host:port>",
+	Short: "Join a classroom as a student",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := args[0]
+		if !strings.Contains(addr, ":") {
+			addr += defaultClassroomAddr
+		}
+
+		if err := ui.RunClassroom(addr, studentName); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	classroomHostCmd.Flags().StringVarP(&classText, "text", "x", "", "Passage to hand out (default: a text of the game mode)")
+	classroomHostCmd.Flags().StringVarP(&classFile, "file", "f", "", "File with the passage to hand out")
+	classroomHostCmd.Flags().StringVarP(&classMode, "mode", "m", "", "Game mode the passage is taken from (default: the saved one)")
+	classroomHostCmd.Flags().IntVar(&classTimeLimit, "time-limit", 0, "Seconds the students get, 0 for no limit")
+	classroomHostCmd.Flags().StringVar(&classErrors, "error-policy", "", "Error policy the students type under (default: their own)")
+	classroomHostCmd.Flags().StringVar(&classBackspace, "backspace", "", "Backspace policy the students type under, off for no backspace (default: their own)")
+	classroomHostCmd.Flags().StringVar(&classCSV, "csv", "", "File the results are exported to (default: classroom-<date>.csv)")
+
+	classroomJoinCmd.Flags().StringVarP(&studentName, "name", "n", os.Getenv("USER"), "Name shown to the instructor")
+
+	classroomCmd.AddCommand(classroomHostCmd)
+	classroomCmd.AddCommand(classroomJoinCmd)
+	rootCmd.AddCommand(classroomCmd)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/prime-run/go-typer/ui"
+	"github.com/spf13/cobra"
+)
+
+var dailyCmd = &cobra.Command{
+	Use:   "daily [share-code]",
+	Short: "Show your daily streak or read a daily share code",
+	Long: `Show today's daily result and your streak, or read a share code someone
+posted (like GT-0K8M-4Z7Q) back into its date, WPM and accuracy.
+
+Play the daily from the start screen or with: go-typer start --daily`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			date, wpm, accuracy, err := ui.DecodeShareCode(args[0])
+			if err != nil {
+				cmd.Printf("Invalid share code: %v\n", err)
+				os.Exit(1)
+			}
+			cmd.Printf("Daily %s: %.1f WPM, %.1f%% accuracy\n", date, wpm, accuracy)
+			return
+		}
+
+		progress, err := ui.LoadDailyProgress()
+		if err != nil {
+			cmd.Println(err)
+			os.Exit(1)
+		}
+
+		today := ui.DailyDate(time.Now())
+		if result, ok := progress.Results[today]; ok && result.Abandoned {
+			cmd.Printf("Daily %s: abandoned, the scored attempt was quit before the end\n", today)
+		} else if ok {
+			cmd.Printf("Daily %s: %.1f WPM, %.1f%% accuracy\n", today, result.WPM, result.Accuracy)
+			if code, err := ui.ShareCode(today, result.WPM, result.Accuracy); err == nil {
+				cmd.Printf("Share code: %s\n", code)
+			}
+		} else {
+			cmd.Printf("Daily %s not played yet, start it with: go-typer start --daily\n", today)
+		}
+		cmd.Printf("Streak: %d (best %d)\n", progress.CurrentStreak(today), progress.BestStreak)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dailyCmd)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/prime-run/go-typer/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	duelNames []string
+	duelText  string
+	duelFile  string
+)
+
+var duelCmd = &cobra.Command{
+	Use:   "duel",
+	Short: "Duel a friend on the same keyboard",
+	Long: `Start a hot-seat duel: two players type the same passage one after the other
+on this machine and their results are compared at the end.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if duelFile != "" {
+			data, err := os.ReadFile(duelFile)
+			if err != nil {
+				cmd.Printf("Could not read text file %s: %v\n", duelFile, err)
+				os.Exit(1)
+			}
+			duelText = string(data)
+		}
+
+		var names [2]string
+		copy(names[:], duelNames)
+		ui.RunDuel(duelText, names)
+	},
+}
+
+func init() {
+	duelCmd.Flags().StringSliceVarP(&duelNames, "names", "n", nil, "Names of the two players, comma separated")
+	duelCmd.Flags().StringVarP(&duelText, "text", "x", "", "Custom text to duel on (default: a text of the game mode)")
+	duelCmd.Flags().StringVarP(&duelFile, "file", "f", "", "Custom text file to duel on")
+
+	rootCmd.AddCommand(duelCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/prime-run/go-typer/leaderboard"
+	"github.com/prime-run/go-typer/ui"
+	"github.com/prime-run/go-typer/utils"
+	"github.com/spf13/cobra"
+)
+
+const defaultLeaderboardAddr = ":7880"
+
+var (
+	leaderboardData   string
+	leaderboardServer string
+	leaderboardMode   string
+	leaderboardSave   bool
+	leaderboardTrust  bool
+)
+
+var leaderboardServerCmd = &cobra.Command{
+	Use:   "leaderboard-server [address]",
+	Short: "Host a leaderboard for your team",
+	Long: `Host a leaderboard. Results submitted from the end screen are kept in a JSON
+file and served over HTTP:
+
+  POST /results        submit a result
+  GET  /results/{id}   a single result with its keystroke log
+  GET  /leaderboard    top results, filtered by ?mode=&length=&user=&limit=&flagged=
+
+Each submission is replayed from its keystroke log like "go-typer verify" does.
+Results that don't check out are kept but flagged, and left off the leaderboard.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := defaultLeaderboardAddr
+		if len(args) > 0 {
+			addr = args[0]
+		}
+
+		store, err := leaderboard.OpenStore(leaderboardData)
+		if err != nil {
+			cmd.Println(err)
+			os.Exit(1)
+		}
+
+		cmd.Printf("Leaderboard listening on %s, results are kept in %s\n", addr, leaderboardData)
+		check := func(entry leaderboard.Entry) []string { return ui.VerifyEntry(entry).Issues }
+		if leaderboardTrust {
+			check = nil
+		}
+		handler := leaderboard.Handler(store, check, func(format string, args ...any) { cmd.Printf(format, args...) })
+		if err := http.ListenAndServe(addr, handler); err != nil {
+			cmd.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var leaderboardCmd = &cobra.Command{
+	Use:   "leaderboard",
+	Short: "Show the leaderboard",
+	Run: func(cmd *cobra.Command, args []string) {
+		server := leaderboardServer
+		if server == "" {
+			server = ui.CurrentSettings.LeaderboardURL
+		}
+		if server == "" {
+			cmd.Println("No leaderboard server set, pass --server or set leaderboard_url in settings.json")
+			os.Exit(1)
+		}
+
+		if leaderboardSave && leaderboardServer != "" {
+			ui.CurrentSettings.LeaderboardURL = leaderboardServer
+			if err := ui.SaveSettings(); err != nil {
+				cmd.Printf("Warning: Could not save settings: %v\n", err)
+			}
+		}
+
+		if err := ui.RunLeaderboard(server, leaderboardMode); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	leaderboardServerCmd.Flags().StringVar(&leaderboardData, "data", filepath.Join(utils.GetConfigDirPath(), "leaderboard.json"), "File the results are kept in")
+	leaderboardServerCmd.Flags().BoolVar(&leaderboardTrust, "trust", false, "Don't verify submissions, accept every result as it is")
+
+	leaderboardCmd.Flags().StringVarP(&leaderboardServer, "server", "s", "", "Leaderboard server URL (default: leaderboard_url from settings.json)")
+	leaderboardCmd.Flags().StringVarP(&leaderboardMode, "mode", "m", "", "Game mode to show first (default: all modes)")
+	leaderboardCmd.Flags().BoolVar(&leaderboardSave, "save", false, "Remember --server, so results can be submitted from the end screen")
+
+	rootCmd.AddCommand(leaderboardServerCmd)
+	rootCmd.AddCommand(leaderboardCmd)
+}
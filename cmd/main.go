@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	devlog "github.com/prime-run/go-typer/log"
@@ -19,6 +20,23 @@ var (
 	debugMode  bool
 	customText string
 	filePath   string
+	language   string
+	layoutName string
+	gameMode   string
+	errPolicy  string
+	backspace  string
+	broadcast  string
+	daily      bool
+	seed       int64
+	wordCount  int
+)
+
+var (
+	botWPMs        []float64
+	botVariability float64
+	botErrors      float64
+	botCorrections float64
+	botSeed        int64
 )
 
 var startCmd = &cobra.Command{
@@ -77,7 +95,97 @@ var startCmd = &cobra.Command{
 			ui.CurrentSettings.CursorType = cursorType
 		}
 
+		if layoutName != "" {
+			if layout, err := ui.LoadKeyboardLayout(layoutName); err != nil {
+				cmd.Printf("Warning: Could not load keyboard layout '%s': %v\n", layoutName, err)
+				cmd.Printf("Available layouts: %s\n", strings.Join(ui.ListAvailableLayouts(), ", "))
+				cmd.Println("Using saved settings")
+			} else {
+				ui.CurrentSettings.KeyboardLayout = strings.ToLower(layoutName)
+				cmd.Printf("Emulating %s layout\n", layout.Name)
+			}
+		}
+
+		if language != "" {
+			if pack, err := ui.LoadLanguagePack(language); err != nil {
+				cmd.Printf("Warning: Could not load language '%s': %v\n", language, err)
+				cmd.Printf("Available languages: %s\n", strings.Join(ui.ListAvailableLanguages(), ", "))
+				cmd.Println("Using saved settings")
+			} else {
+				ui.CurrentSettings.Language = pack.Code
+			}
+		}
+
+		if gameMode != "" {
+			if slices.Contains(ui.GameModeNames(), gameMode) {
+				ui.CurrentSettings.GameMode = gameMode
+			} else {
+				cmd.Printf("Warning: Unknown game mode '%s'. Available modes: %s\n", gameMode, strings.Join(ui.GameModeNames(), ", "))
+				cmd.Println("Using saved settings")
+			}
+		}
+
+		if errPolicy != "" {
+			if policy := ui.ParseErrorPolicy(errPolicy); string(policy) == strings.ToLower(errPolicy) {
+				ui.CurrentSettings.ErrorPolicy = string(policy)
+			} else {
+				cmd.Printf("Warning: Unknown error policy '%s'. Available policies: free, stop_on_letter, stop_on_word\n", errPolicy)
+				cmd.Println("Using saved settings")
+			}
+		}
+
+		if backspace != "" {
+			if policy := ui.ParseBackspacePolicy(backspace); string(policy) == strings.ToLower(backspace) {
+				ui.CurrentSettings.BackspacePolicy = string(policy)
+			} else {
+				cmd.Printf("Warning: Unknown backspace policy '%s'. Available policies: free, word, off\n", backspace)
+				cmd.Println("Using saved settings")
+			}
+		}
+
+		if broadcast != "" {
+			addr, err := ui.StartBroadcast(broadcast)
+			if err != nil {
+				cmd.Printf("Could not start broadcast: %v\n", err)
+				os.Exit(1)
+			}
+			defer ui.StopBroadcast()
+			cmd.Printf("Broadcasting on %s, watch with: go-typer watch <host:port>\n", addr)
+		}
+
+		if wordCount > 0 {
+			ui.CurrentSettings.WordCount = wordCount
+		}
+
+		// NOTE: 0 is a seed like any other, only an unset flag means random
+		if cmd.Flags().Changed("seed") {
+			ui.SeedText(seed)
+			if !cmd.Flags().Changed("bot-seed") {
+				botSeed = seed
+			}
+		}
+
+		for _, wpm := range botWPMs {
+			ui.BotOpponents = append(ui.BotOpponents, ui.BotProfile{
+				WPM:            wpm,
+				Variability:    botVariability,
+				ErrorRate:      botErrors,
+				CorrectionRate: botCorrections,
+			})
+		}
+		ui.BotSeed = botSeed
+		ui.BotSeeded = cmd.Flags().Changed("seed") || cmd.Flags().Changed("bot-seed")
+
 		ui.ApplySettings()
+
+		if daily {
+			if customText != "" {
+				cmd.Println("Warning: --daily plays the passage of the day, ignoring the custom text")
+			}
+			ui.RunDaily()
+			return
+		}
+
 		ui.StartLoadingWithOptions(ui.CurrentSettings.CursorType, customText)
 	},
 }
@@ -92,6 +200,20 @@ func init() {
 
 	startCmd.Flags().StringVarP(&customText, "text", "x", "", "Custom text to display in the game (default: random text)")
 	startCmd.Flags().StringVarP(&filePath, "file", "f", "", "Custom text file to read from")
+	startCmd.Flags().StringVar(&layoutName, "layout", "", "Keyboard layout to emulate on a QWERTY keyboard (qwerty, dvorak, colemak, workman or a custom layout file)")
+	startCmd.Flags().StringVarP(&gameMode, "mode", "m", "", "Game mode (normal, simple, timed, words, zen, lessons, code)")
+	startCmd.Flags().StringVar(&errPolicy, "error-policy", "", "What happens on a wrong key (free, stop_on_letter or stop_on_word)")
+	startCmd.Flags().StringVar(&backspace, "backspace", "", "How far backspace can walk back (free, word or off)")
+	startCmd.Flags().StringVar(&broadcast, "broadcast", "", "Stream the session to watchers on this address (e.g. :7879)")
+	startCmd.Flags().Float64SliceVar(&botWPMs, "bots", nil, "Race bots typing at these speeds in WPM (e.g. 40,60,80)")
+	startCmd.Flags().Float64Var(&botVariability, "bot-variability", 0.2, "How much the bots' speed varies from key to key, 0 to 1")
+	startCmd.Flags().Float64Var(&botErrors, "bot-errors", 0.03, "Chance a bot mistypes a letter, 0 to 1")
+	startCmd.Flags().Float64Var(&botCorrections, "bot-corrections", 0.8, "Chance a bot fixes a mistyped letter, 0 to 1")
+	startCmd.Flags().Int64Var(&botSeed, "bot-seed", 0, "Seed for the bots' typing, the same seed replays the same race (default: random)")
+	startCmd.Flags().Int64Var(&seed, "seed", 0, "Seed for the generated text (and the bots), the same seed gives the same passages on any machine, with default text settings (default: random)")
+	startCmd.Flags().IntVar(&wordCount, "words", 0, "Words per game in words mode (default: word_count from settings.json)")
+	startCmd.Flags().BoolVar(&daily, "daily", false, "Play the passage of the day, the same for everyone with one scored attempt")
+	startCmd.Flags().StringVarP(&language, "lang", "l", "", "Language pack to take words and quotes from (e.g. en, de, fr)")
 
 	rootCmd.AddCommand(startCmd)
 }
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/prime-run/go-typer/race"
+	"github.com/prime-run/go-typer/ui"
+	"github.com/spf13/cobra"
+)
+
+const defaultRaceAddr = ":7878"
+
+var (
+	racePlayers   int
+	raceCountdown int
+	raceText      string
+	raceFile      string
+	raceMode      string
+	racerName     string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [address]",
+	Short: "Host a typing race on the local network",
+	Long: `Host a typing race. Racers join with "go-typer join <host:port>", the countdown
+starts once enough of them are in the lobby and everyone types the same passage.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := defaultRaceAddr
+		if len(args) > 0 {
+			addr = args[0]
+		}
+
+		if raceFile != "" {
+			data, err := os.ReadFile(raceFile)
+			if err != nil {
+				cmd.Printf("Could not read text file %s: %v\n", raceFile, err)
+				os.Exit(1)
+			}
+			raceText = string(data)
+		}
+
+		if raceMode != "" {
+			if !slices.Contains(ui.GameModeNames(), raceMode) {
+				cmd.Printf("Unknown game mode '%s'. Available modes: %s\n", raceMode, strings.Join(ui.GameModeNames(), ", "))
+				os.Exit(1)
+			}
+			ui.CurrentSettings.GameMode = raceMode
+		}
+
+		server := race.NewServer(ui.GameText(raceText), racePlayers)
+		server.Countdown = raceCountdown
+		server.Logf = func(format string, args ...any) { cmd.Printf(format, args...) }
+
+		if err := server.Listen(addr); err != nil {
+			cmd.Println(err)
+			os.Exit(1)
+		}
+		cmd.Printf("Race server listening on %s, waiting for %d racers\n", server.Addr(), server.Players)
+
+		if err := server.Serve(); err != nil {
+			cmd.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var joinCmd = &cobra.Command{
+	Use:   "join <host:port>",
+	Short: "Join a typing race",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := args[0]
+		if !strings.Contains(addr, ":") {
+			addr += defaultRaceAddr
+		}
+
+		if err := ui.RunRace(addr, racerName); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <host:port>",
+	Short: "Watch a session broadcast with start --broadcast",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ui.RunWatch(args[0]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	serveCmd.Flags().IntVarP(&racePlayers, "players", "p", 2, "Racers needed before the countdown starts")
+	serveCmd.Flags().IntVar(&raceCountdown, "countdown", 3, "Seconds counted down before the start")
+	serveCmd.Flags().StringVarP(&raceText, "text", "x", "", "Custom text to race on (default: a text of the game mode)")
+	serveCmd.Flags().StringVarP(&raceFile, "file", "f", "", "Custom text file to race on")
+	serveCmd.Flags().StringVarP(&raceMode, "mode", "m", "", "Game mode the passage is taken from (default: the saved one)")
+
+	joinCmd.Flags().StringVarP(&racerName, "name", "n", os.Getenv("USER"), "Name shown to the other racers")
+
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(joinCmd)
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/prime-run/go-typer/leaderboard"
+	"github.com/prime-run/go-typer/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyServer string
+	verifyID     int
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [result.json]",
+	Short: "Replay a result's keystroke log and check its score",
+	Long: `Replay the keystroke log of a result through the typing engine, recompute its
+WPM and accuracy and flag results that don't match their log or couldn't have
+been typed by hand (pasted text, keys less than 10ms apart, ...).
+
+Checks the last game played by default, a result file, or a leaderboard entry
+with --id. Exits with status 1 if the result doesn't check out.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var verification ui.Verification
+		var claimedWPM, claimedAccuracy float64
+
+		if verifyID > 0 {
+			server := verifyServer
+			if server == "" {
+				server = ui.CurrentSettings.LeaderboardURL
+			}
+			if server == "" {
+				cmd.Println("No leaderboard server set, pass --server or set leaderboard_url in settings.json")
+				os.Exit(1)
+			}
+
+			entry, err := leaderboard.NewClient(server).Get(verifyID)
+			if err != nil {
+				cmd.Println(err)
+				os.Exit(1)
+			}
+			cmd.Printf("Result #%d by %s, %s (%s)\n", entry.ID, entry.User, entry.Mode, entry.Length)
+			verification = ui.VerifyEntry(entry)
+			claimedWPM, claimedAccuracy = entry.WPM, entry.Accuracy
+		} else {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			} else {
+				lastPath, err := ui.GetLastResultFilePath()
+				if err != nil {
+					cmd.Println(err)
+					os.Exit(1)
+				}
+				path = lastPath
+			}
+
+			result, err := ui.LoadResultLog(path)
+			if err != nil {
+				cmd.Println(err)
+				os.Exit(1)
+			}
+			cmd.Printf("Result from %s, %s mode\n", path, result.Mode)
+			verification = ui.Verify(result)
+			claimedWPM, claimedAccuracy = result.WPM, result.Accuracy
+		}
+
+		cmd.Printf("Claimed:    %6.1f WPM  %5.1f%% accuracy\n", claimedWPM, claimedAccuracy)
+		cmd.Printf("Recomputed: %6.1f WPM  %5.1f%% accuracy\n", verification.Result.WPM, verification.Result.Accuracy)
+
+		if verification.OK() {
+			cmd.Println("OK: the result matches its keystroke log")
+			return
+		}
+
+		cmd.Println("Flagged:")
+		for _, issue := range verification.Issues {
+			cmd.Printf("  - %s\n", issue)
+		}
+		os.Exit(1)
+	},
+}
+
+func init() {
+	verifyCmd.Flags().StringVarP(&verifyServer, "server", "s", "", "Leaderboard server to fetch --id from (default: leaderboard_url from settings.json)")
+	verifyCmd.Flags().IntVar(&verifyID, "id", 0, "Verify this leaderboard entry instead of a result file")
+
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,112 @@
+package leaderboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client talks to a leaderboard server.
+type Client struct {
+	BaseURL string // e.g. http://typing.example.lan:7880
+	HTTP    *http.Client
+}
+
+// NewClient returns a client for the server at baseURL. A missing scheme
+// means http.
+func NewClient(baseURL string) *Client {
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "http://" + baseURL
+	}
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		HTTP:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Submit sends entry and returns it as stored by the server.
+func (c *Client) Submit(entry Entry) (Entry, error) {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return entry, fmt.Errorf("error marshaling entry: %w", err)
+	}
+
+	resp, err := c.HTTP.Post(c.BaseURL+"/results", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return entry, fmt.Errorf("could not reach the leaderboard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var stored Entry
+	if err := decode(resp, &stored); err != nil {
+		return entry, err
+	}
+	return stored, nil
+}
+
+// Top fetches a leaderboard.
+func (c *Client) Top(query Query) ([]Entry, error) {
+	values := url.Values{}
+	if query.Mode != "" {
+		values.Set("mode", query.Mode)
+	}
+	if query.Length != "" {
+		values.Set("length", query.Length)
+	}
+	if query.User != "" {
+		values.Set("user", query.User)
+	}
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Flagged {
+		values.Set("flagged", "true")
+	}
+
+	resp, err := c.HTTP.Get(c.BaseURL + "/leaderboard?" + values.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("could not reach the leaderboard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var entries []Entry
+	if err := decode(resp, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Get fetches a single entry with its passage and keystroke log.
+func (c *Client) Get(id int) (Entry, error) {
+	resp, err := c.HTTP.Get(fmt.Sprintf("%s/results/%d", c.BaseURL, id))
+	if err != nil {
+		return Entry{}, fmt.Errorf("could not reach the leaderboard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var entry Entry
+	if err := decode(resp, &entry); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// decode reads a JSON response into v, or the server's error.
+func decode(resp *http.Response, v any) error {
+	if resp.StatusCode >= 300 {
+		var apiErr apiError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error != "" {
+			return fmt.Errorf("leaderboard: %s", apiErr.Error)
+		}
+		return fmt.Errorf("leaderboard: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error parsing leaderboard response: %w", err)
+	}
+	return nil
+}
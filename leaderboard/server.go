@@ -0,0 +1,110 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxEntryBytes caps a submitted entry, keystroke log included.
+const maxEntryBytes = 1 << 20
+
+// Handler serves the store:
+//
+//	POST /results          submit an Entry, answers with the stored entry
+//	GET  /results/{id}     a single entry with its keystroke log
+//	GET  /leaderboard      top entries, filtered by ?mode=&length=&user=&limit=&flagged=
+//
+// check vets each submission, the issues it returns are kept as the entry's
+// flags. A nil check trusts every result.
+func Handler(store *Store, check func(Entry) []string, logf func(format string, args ...any)) http.Handler {
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /results", func(w http.ResponseWriter, r *http.Request) {
+		var entry Entry
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxEntryBytes)).Decode(&entry); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid entry: %w", err))
+			return
+		}
+
+		entry.Flags = nil
+		if check != nil {
+			entry.Flags = check(entry)
+		}
+
+		entry, err := store.Add(entry)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		logf("%s submitted %.1f WPM (%.1f%%) in %s\n", entry.User, entry.WPM, entry.Accuracy, entry.Mode)
+		for _, flag := range entry.Flags {
+			logf("  flagged: %s\n", flag)
+		}
+		writeJSON(w, http.StatusCreated, entry)
+	})
+
+	mux.HandleFunc("GET /results/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.New("invalid entry ID"))
+			return
+		}
+		entry, ok := store.Get(id)
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.New("no such entry"))
+			return
+		}
+		writeJSON(w, http.StatusOK, entry)
+	})
+
+	mux.HandleFunc("GET /leaderboard", func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+		query := Query{
+			Mode:    values.Get("mode"),
+			Length:  values.Get("length"),
+			User:    values.Get("user"),
+			Limit:   50,
+			Flagged: values.Get("flagged") == "true",
+		}
+		if limit := values.Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil || n < 0 {
+				writeError(w, http.StatusBadRequest, errors.New("invalid limit"))
+				return
+			}
+			query.Limit = n
+		}
+
+		entries := store.Top(query)
+		if entries == nil {
+			entries = []Entry{}
+		}
+		writeJSON(w, http.StatusOK, entries)
+	})
+
+	return mux
+}
+
+// apiError is the body of an error response.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, apiError{Error: strings.TrimSpace(err.Error())})
+}
@@ -0,0 +1,190 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// startServer serves a fresh store, vetting submissions with check.
+func startServer(t *testing.T, check func(Entry) []string) *Client {
+	t.Helper()
+
+	store, err := OpenStore(filepath.Join(t.TempDir(), "leaderboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(Handler(store, check, nil))
+	t.Cleanup(server.Close)
+	return NewClient(server.URL)
+}
+
+func TestSubmitAndList(t *testing.T) {
+	client := startServer(t, nil)
+
+	keystrokes := json.RawMessage(`[{"at":0,"action":"type","text":"a"}]`)
+	stored, err := client.Submit(Entry{User: "ada", Mode: "words", Length: "short", WPM: 62.5, Accuracy: 98, Text: "a", PassageHash: PassageHash("a"), Keystrokes: keystrokes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.ID != 1 || stored.User != "ada" || stored.Submitted.IsZero() {
+		t.Errorf("stored entry %+v", stored)
+	}
+	if _, err := client.Submit(Entry{User: "linus", Mode: "timed", WPM: 80, Accuracy: 95}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := client.Top(Query{Mode: "words"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].User != "ada" || entries[0].WPM != 62.5 {
+		t.Fatalf("words leaderboard %+v, want ada's entry", entries)
+	}
+	if entries[0].Keystrokes != nil || entries[0].Text != "" {
+		t.Error("the leaderboard carries passages and keystroke logs")
+	}
+
+	full, err := client.Get(stored.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Text != "a" || string(full.Keystrokes) != string(keystrokes) {
+		t.Errorf("entry %d came back without its passage or log: %+v", stored.ID, full)
+	}
+
+	if all, err := client.Top(Query{}); err != nil || len(all) != 2 {
+		t.Errorf("full leaderboard %+v (%v), want both entries", all, err)
+	}
+}
+
+func TestSubmitRejected(t *testing.T) {
+	client := startServer(t, nil)
+
+	tests := []struct {
+		name    string
+		entry   Entry
+		wantErr string
+	}{
+		{"no user", Entry{Mode: "words", WPM: 60, Accuracy: 90}, "user name is required"},
+		{"no mode", Entry{User: "ada", WPM: 60, Accuracy: 90}, "game mode is required"},
+		{"negative wpm", Entry{User: "ada", Mode: "words", WPM: -5, Accuracy: 90}, "out of range"},
+		{"accuracy over 100", Entry{User: "ada", Mode: "words", WPM: 60, Accuracy: 150}, "out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Submit(tt.entry)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error %v, want one about %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if entries, err := client.Top(Query{Flagged: true}); err != nil || len(entries) != 0 {
+		t.Errorf("leaderboard %+v (%v), want nothing stored", entries, err)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	client := startServer(t, nil)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"not json", http.MethodPost, "/results", "{not json", http.StatusBadRequest},
+		{"wrong types", http.MethodPost, "/results", `{"user":"ada","wpm":"fast"}`, http.StatusBadRequest},
+		{"too big", http.MethodPost, "/results", `{"user":"` + strings.Repeat("a", maxEntryBytes) + `"}`, http.StatusBadRequest},
+		{"invalid id", http.MethodGet, "/results/abc", "", http.StatusBadRequest},
+		{"unknown id", http.MethodGet, "/results/99", "", http.StatusNotFound},
+		{"negative limit", http.MethodGet, "/leaderboard?limit=-1", "", http.StatusBadRequest},
+		{"limit not a number", http.MethodGet, "/leaderboard?limit=ten", "", http.StatusBadRequest},
+		{"wrong method", http.MethodDelete, "/results/1", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, client.BaseURL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.HTTP.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestFlaggedSubmission(t *testing.T) {
+	check := func(entry Entry) []string {
+		if entry.WPM > 250 {
+			return []string{"beyond human"}
+		}
+		return nil
+	}
+	client := startServer(t, check)
+
+	honest, err := client.Submit(Entry{User: "ada", Mode: "words", WPM: 70, Accuracy: 97})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cheat, err := client.Submit(Entry{User: "mallory", Mode: "words", WPM: 400, Accuracy: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(honest.Flags) != 0 || len(cheat.Flags) != 1 {
+		t.Fatalf("flags %q and %q, want only the cheat flagged", honest.Flags, cheat.Flags)
+	}
+
+	// flags come from the server's check, never from the submission
+	if trusted, err := client.Submit(Entry{User: "eve", Mode: "words", WPM: 60, Accuracy: 90, Flags: []string{"flag myself"}}); err != nil || len(trusted.Flags) != 0 {
+		t.Errorf("submitted flags were kept: %q (%v)", trusted.Flags, err)
+	}
+
+	entries, err := client.Top(Query{Mode: "words"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.User == "mallory" {
+			t.Error("a flagged result made the leaderboard")
+		}
+	}
+
+	flagged, err := client.Top(Query{Mode: "words", Flagged: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flagged) != 3 || flagged[0].User != "mallory" {
+		t.Errorf("leaderboard with flagged results %+v, want mallory on top", flagged)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"typing.lan:7880", "http://typing.lan:7880"},
+		{"http://typing.lan:7880/", "http://typing.lan:7880"},
+		{"https://typing.example.com", "https://typing.example.com"},
+	}
+	for _, tt := range tests {
+		if got := NewClient(tt.url).BaseURL; got != tt.want {
+			t.Errorf("NewClient(%q).BaseURL = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
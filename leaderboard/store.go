@@ -0,0 +1,176 @@
+// Package leaderboard keeps submitted typing results in a file-backed store
+// and serves them as leaderboards over HTTP/JSON.
+package leaderboard
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a submitted result.
+type Entry struct {
+	ID          int             `json:"id"`
+	User        string          `json:"user"`
+	Mode        string          `json:"mode"`   // game mode the result was typed in
+	Length      string          `json:"length"` // text length setting (short, medium, ...)
+	WPM         float64         `json:"wpm"`
+	Accuracy    float64         `json:"accuracy"`
+	PassageHash string          `json:"passage_hash"`         // PassageHash of the typed text
+	Keystrokes  json.RawMessage `json:"keystrokes,omitempty"` // keystroke log of the game, kept as sent
+	Submitted   time.Time       `json:"submitted"`
+
+	// What it takes to replay the keystroke log
+	Text            string        `json:"text,omitempty"`
+	Elapsed         time.Duration `json:"elapsed,omitempty"`
+	TimeLimit       time.Duration `json:"time_limit,omitempty"` // limit of a timed game
+	ErrorPolicy     string        `json:"error_policy,omitempty"`
+	BackspacePolicy string        `json:"backspace_policy,omitempty"`
+
+	Flags []string `json:"flags,omitempty"` // why the server doesn't trust the result, left off leaderboards
+}
+
+// Query selects the entries of a leaderboard. Empty fields match anything.
+type Query struct {
+	Mode    string
+	Length  string
+	User    string
+	Limit   int  // 0 for no limit
+	Flagged bool // include flagged entries
+}
+
+// PassageHash identifies a passage without storing it.
+func PassageHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Store keeps entries in a JSON file, rewritten on every change.
+type Store struct {
+	path    string
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// OpenStore loads the store at path, an empty one if the file doesn't
+// exist yet.
+func OpenStore(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("error reading leaderboard file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("error parsing leaderboard file: %w", err)
+	}
+	return s, nil
+}
+
+// Add validates entry, stores it and returns it with its ID and time set.
+func (s *Store) Add(entry Entry) (Entry, error) {
+	entry.User = strings.Join(strings.Fields(entry.User), " ")
+	switch {
+	case entry.User == "":
+		return entry, fmt.Errorf("a user name is required")
+	case entry.Mode == "":
+		return entry, fmt.Errorf("a game mode is required")
+	case entry.WPM < 0 || entry.Accuracy < 0 || entry.Accuracy > 100:
+		return entry, fmt.Errorf("WPM and accuracy are out of range")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.ID = 1
+	if n := len(s.entries); n > 0 {
+		entry.ID = s.entries[n-1].ID + 1
+	}
+	entry.Submitted = time.Now().UTC()
+
+	s.entries = append(s.entries, entry)
+	if err := s.save(); err != nil {
+		s.entries = s.entries[:len(s.entries)-1]
+		return entry, err
+	}
+	return entry, nil
+}
+
+// save writes the entries to a temporary file and moves it over the store,
+// so a crash never leaves half a file behind. The caller holds s.mu.
+func (s *Store) save() error {
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return fmt.Errorf("error marshaling leaderboard: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("error creating leaderboard directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("error writing leaderboard file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("error writing leaderboard file: %w", err)
+	}
+	return nil
+}
+
+// Top returns the entries matching query, fastest first. Passages and
+// keystroke logs are left out, Get returns a single entry with them.
+func (s *Store) Top(query Query) []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entries []Entry
+	for _, entry := range s.entries {
+		if (query.Mode != "" && entry.Mode != query.Mode) ||
+			(query.Length != "" && entry.Length != query.Length) ||
+			(query.User != "" && entry.User != query.User) ||
+			(len(entry.Flags) > 0 && !query.Flagged) {
+			continue
+		}
+		entry.Keystrokes = nil
+		entry.Text = ""
+		entries = append(entries, entry)
+	}
+
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		if c := cmp.Compare(b.WPM, a.WPM); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Accuracy, a.Accuracy)
+	})
+
+	if query.Limit > 0 && len(entries) > query.Limit {
+		entries = entries[:query.Limit]
+	}
+	return entries
+}
+
+// Get returns the entry with id.
+func (s *Store) Get(id int) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range s.entries {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
@@ -0,0 +1,150 @@
+package leaderboard
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "leaderboard.json")
+
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added := []Entry{
+		{User: "  ada   lovelace ", Mode: "words", Length: "short", WPM: 62, Accuracy: 97, Text: "the quick", Keystrokes: json.RawMessage(`[{"at":0}]`)},
+		{User: "linus", Mode: "words", Length: "short", WPM: 81, Accuracy: 95},
+		{User: "grace", Mode: "timed", WPM: 70, Accuracy: 99, Flags: []string{"looks pasted"}},
+	}
+	for i, entry := range added {
+		stored, err := store.Add(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.ID != i+1 || stored.Submitted.IsZero() {
+			t.Errorf("entry %d stored with ID %d at %v", i+1, stored.ID, stored.Submitted)
+		}
+	}
+
+	reopened, err := OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, ok := reopened.Get(1)
+	switch {
+	case !ok:
+		t.Fatal("entry 1 is gone after reopening")
+	case entry.User != "ada lovelace":
+		t.Errorf("user %q, want the name cleaned up", entry.User)
+	case entry.Text != "the quick" || string(entry.Keystrokes) != `[{"at":0}]`:
+		t.Errorf("entry 1 lost its passage or keystrokes: %+v", entry)
+	}
+	if _, ok := reopened.Get(4); ok {
+		t.Error("found an entry that was never added")
+	}
+
+	next, err := reopened.Add(Entry{User: "alan", Mode: "zen", WPM: 50, Accuracy: 90})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.ID != 4 {
+		t.Errorf("ID %d after reopening, want 4", next.ID)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+}
+
+func TestStoreAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   Entry
+		wantErr string // part of the error, empty for none
+	}{
+		{"valid", Entry{User: "ada", Mode: "normal", WPM: 60, Accuracy: 100}, ""},
+		{"no user", Entry{User: " \t", Mode: "normal", WPM: 60, Accuracy: 90}, "user name is required"},
+		{"no mode", Entry{User: "ada", WPM: 60, Accuracy: 90}, "game mode is required"},
+		{"negative wpm", Entry{User: "ada", Mode: "normal", WPM: -1, Accuracy: 90}, "out of range"},
+		{"accuracy over 100", Entry{User: "ada", Mode: "normal", WPM: 60, Accuracy: 101}, "out of range"},
+	}
+
+	store, err := OpenStore(filepath.Join(t.TempDir(), "leaderboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := store.Add(tt.entry)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("error %v, want one about %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if entries := store.Top(Query{}); len(entries) != 1 {
+		t.Errorf("%d entries stored, want only the valid one", len(entries))
+	}
+}
+
+func TestOpenStoreCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenStore(path); err == nil {
+		t.Error("opened a corrupt store")
+	}
+}
+
+func TestStoreTop(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "leaderboard.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []Entry{
+		{User: "ada", Mode: "words", Length: "short", WPM: 60, Accuracy: 90},
+		{User: "linus", Mode: "words", Length: "long", WPM: 80, Accuracy: 95},
+		{User: "grace", Mode: "words", Length: "short", WPM: 60, Accuracy: 99},
+		{User: "alan", Mode: "timed", WPM: 90, Accuracy: 97},
+		{User: "mallory", Mode: "words", WPM: 300, Accuracy: 100, Flags: []string{"beyond human"}},
+	} {
+		if _, err := store.Add(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string // users, in order
+	}{
+		{"everything", Query{}, []string{"alan", "linus", "grace", "ada"}},
+		{"mode", Query{Mode: "words"}, []string{"linus", "grace", "ada"}},
+		{"length", Query{Mode: "words", Length: "short"}, []string{"grace", "ada"}},
+		{"user", Query{User: "ada"}, []string{"ada"}},
+		{"limit", Query{Limit: 2}, []string{"alan", "linus"}},
+		{"flagged", Query{Mode: "words", Flagged: true}, []string{"mallory", "linus", "grace", "ada"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var users []string
+			for _, entry := range store.Top(tt.query) {
+				users = append(users, entry.User)
+			}
+			if strings.Join(users, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Top(%+v) = %v, want %v", tt.query, users, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package race
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// watcherBuffer is how many messages a watcher may lag behind before it is
+// dropped, a slow watcher never holds up the game.
+const watcherBuffer = 1024
+
+// Broadcaster streams a game session to read-only watchers. Watchers that
+// connect mid-game first get everything sent since the session started.
+type Broadcaster struct {
+	listener net.Listener
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	history  []Message // messages of the current session
+	closed   bool
+}
+
+type watcher struct {
+	conn net.Conn
+	out  chan Message
+}
+
+// NewBroadcaster starts accepting watchers on addr.
+func NewBroadcaster(addr string) (*Broadcaster, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("could not listen on %s: %w", addr, err)
+	}
+
+	b := &Broadcaster{
+		listener: listener,
+		watchers: make(map[*watcher]struct{}),
+	}
+	go b.accept()
+	return b, nil
+}
+
+// Addr returns the address watchers connect to.
+func (b *Broadcaster) Addr() net.Addr {
+	return b.listener.Addr()
+}
+
+func (b *Broadcaster) accept() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			conn.Close()
+			return
+		}
+		w := &watcher{conn: conn, out: make(chan Message, watcherBuffer+len(b.history))}
+		for _, msg := range b.history {
+			w.out <- msg
+		}
+		b.watchers[w] = struct{}{}
+		b.mu.Unlock()
+
+		go w.write()
+	}
+}
+
+func (w *watcher) write() {
+	defer w.conn.Close()
+	enc := json.NewEncoder(w.conn)
+	for msg := range w.out {
+		if err := enc.Encode(msg); err != nil {
+			return
+		}
+	}
+}
+
+// Send streams msg to every watcher. A session message starts a new history.
+func (b *Broadcaster) Send(msg Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	if msg.Type == MsgSession {
+		b.history = b.history[:0]
+	}
+	b.history = append(b.history, msg)
+
+	for w := range b.watchers {
+		select {
+		case w.out <- msg:
+		default:
+			delete(b.watchers, w)
+			close(w.out)
+		}
+	}
+}
+
+// Close stops the broadcast and disconnects the watchers.
+func (b *Broadcaster) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for w := range b.watchers {
+		close(w.out)
+	}
+	b.watchers = nil
+	return b.listener.Close()
+}
@@ -0,0 +1,292 @@
+package race
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Assignment is what the instructor hands out: a passage and the rules it
+// is typed under.
+type Assignment struct {
+	Text            string
+	TimeLimit       int    // seconds, 0 for none
+	ErrorPolicy     string // empty keeps the student's own
+	BackspacePolicy string // empty keeps the student's own
+}
+
+// ClassResult is a student's finished round.
+type ClassResult struct {
+	Round    int
+	ID       int
+	Name     string
+	WPM      float64
+	Accuracy float64
+	Correct  int
+	Mistakes int
+	Elapsed  time.Duration
+	At       time.Time // when the result came in
+}
+
+// Classroom hosts a workshop: students connect at any time, the instructor
+// starts rounds on the assignment and results are collected as they come in.
+type Classroom struct {
+	Assignment Assignment
+	Logf       func(format string, args ...any) // classroom events, nil to stay quiet
+
+	listener net.Listener
+	mu       sync.Mutex
+	clients  map[int]*client
+	nextID   int
+	round    int
+	results  []ClassResult
+	closed   bool
+}
+
+// NewClassroom returns a classroom handing out assignment.
+func NewClassroom(assignment Assignment) *Classroom {
+	return &Classroom{
+		Assignment: assignment,
+		clients:    make(map[int]*client),
+	}
+}
+
+// Listen opens the classroom's TCP port.
+func (c *Classroom) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %w", addr, err)
+	}
+	c.listener = listener
+	return nil
+}
+
+// Addr returns the address the classroom listens on.
+func (c *Classroom) Addr() net.Addr {
+	return c.listener.Addr()
+}
+
+// Serve accepts students until the classroom is closed.
+func (c *Classroom) Serve() error {
+	if c.listener == nil {
+		return errors.New("classroom is not listening")
+	}
+
+	for {
+		conn, err := c.listener.Accept()
+		if err != nil {
+			c.mu.Lock()
+			closed := c.closed
+			c.mu.Unlock()
+			if closed {
+				return nil
+			}
+			return fmt.Errorf("could not accept student: %w", err)
+		}
+		go c.handle(conn)
+	}
+}
+
+// Close ends the workshop and disconnects everyone.
+func (c *Classroom) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	if c.listener != nil {
+		c.listener.Close()
+	}
+	for _, cl := range c.clients {
+		cl.close()
+	}
+}
+
+func (c *Classroom) logf(format string, args ...any) {
+	if c.Logf != nil {
+		c.Logf(format, args...)
+	}
+}
+
+func (c *Classroom) handle(conn net.Conn) {
+	cl := newClient(conn)
+	defer cl.close()
+
+	dec := json.NewDecoder(conn)
+
+	var join Message
+	if err := dec.Decode(&join); err != nil || join.Type != MsgJoin {
+		cl.send(Message{Type: MsgError, Error: "expected a join message"})
+		return
+	}
+
+	if !c.join(cl, join.Name) {
+		return
+	}
+	defer c.leave(cl)
+
+	for {
+		var msg Message
+		if err := dec.Decode(&msg); err != nil {
+			return
+		}
+		c.receive(cl, msg)
+	}
+}
+
+func (c *Classroom) join(cl *client, name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		cl.send(Message{Type: MsgError, Error: "the classroom is closed"})
+		return false
+	}
+
+	c.nextID++
+	cl.racer = Racer{ID: c.nextID, Name: cleanName(name, c.nextID)}
+	c.clients[cl.racer.ID] = cl
+	c.logf("%s joined\n", cl.racer.Name)
+
+	cl.send(Message{Type: MsgWelcome, ID: cl.racer.ID, Name: cl.racer.Name})
+	// NOTE: latecomers start on the current round right away
+	if c.round > 0 {
+		cl.send(c.assignment())
+	}
+	return true
+}
+
+func (c *Classroom) leave(cl *client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.clients[cl.racer.ID]; !ok {
+		return
+	}
+	c.logf("%s left\n", cl.racer.Name)
+	cl.racer.Left = true
+}
+
+func (c *Classroom) receive(cl *client, msg Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.round == 0 || cl.racer.Finished || msg.Round != c.round {
+		return
+	}
+
+	switch msg.Type {
+	case MsgProgress:
+		cl.racer.Word = msg.Word
+		cl.racer.Letter = msg.Letter
+		cl.racer.Progress = clamp(msg.Progress)
+		cl.racer.WPM = msg.WPM
+	case MsgFinish:
+		cl.racer.Finished = true
+		cl.racer.Progress = 1
+		cl.racer.WPM = msg.WPM
+		cl.racer.Accuracy = msg.Accuracy
+		c.results = append(c.results, ClassResult{
+			Round:    c.round,
+			ID:       cl.racer.ID,
+			Name:     cl.racer.Name,
+			WPM:      msg.WPM,
+			Accuracy: msg.Accuracy,
+			Correct:  msg.Correct,
+			Mistakes: msg.Mistakes,
+			Elapsed:  msg.At,
+			At:       time.Now(),
+		})
+		c.logf("%s finished with %.1f WPM (%.1f%% accuracy)\n", cl.racer.Name, msg.WPM, msg.Accuracy)
+	}
+}
+
+// Start begins a new round: every student gets the assignment and their
+// progress is reset.
+func (c *Classroom) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.round++
+	c.logf("Round %d started\n", c.round)
+	for _, cl := range c.clients {
+		if cl.racer.Left {
+			continue
+		}
+		cl.racer = Racer{ID: cl.racer.ID, Name: cl.racer.Name}
+		if err := cl.send(c.assignment()); err != nil {
+			c.logf("Could not reach %s: %v\n", cl.racer.Name, err)
+		}
+	}
+}
+
+// assignment is the message starting the current round. The caller holds c.mu.
+func (c *Classroom) assignment() Message {
+	return Message{
+		Type:            MsgAssignment,
+		Round:           c.round,
+		Text:            c.Assignment.Text,
+		TimeLimit:       c.Assignment.TimeLimit,
+		ErrorPolicy:     c.Assignment.ErrorPolicy,
+		BackspacePolicy: c.Assignment.BackspacePolicy,
+	}
+}
+
+// Round returns the current round, 0 before the first one started.
+func (c *Classroom) Round() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.round
+}
+
+// Students returns everyone who joined, in join order, with their state in
+// the current round.
+func (c *Classroom) Students() []Racer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	students := make([]Racer, 0, len(c.clients))
+	for _, cl := range c.clients {
+		students = append(students, cl.racer)
+	}
+	slices.SortFunc(students, func(a, b Racer) int { return cmp.Compare(a.ID, b.ID) })
+	return students
+}
+
+// Results returns the finished games of every round, in the order they
+// came in.
+func (c *Classroom) Results() []ClassResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.results)
+}
+
+// WriteCSV writes the results of every round as CSV.
+func (c *Classroom) WriteCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"round", "student", "wpm", "accuracy", "correct_words", "wrong_words", "seconds", "finished_at"})
+	for _, result := range c.Results() {
+		w.Write([]string{
+			strconv.Itoa(result.Round),
+			result.Name,
+			strconv.FormatFloat(result.WPM, 'f', 1, 64),
+			strconv.FormatFloat(result.Accuracy, 'f', 1, 64),
+			strconv.Itoa(result.Correct),
+			strconv.Itoa(result.Mistakes),
+			strconv.FormatFloat(result.Elapsed.Seconds(), 'f', 1, 64),
+			result.At.Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
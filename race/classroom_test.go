@@ -0,0 +1,105 @@
+package race
+
+import (
+	"testing"
+	"time"
+)
+
+// startClassroom runs a classroom on a free loopback port.
+func startClassroom(t *testing.T, assignment Assignment) *Classroom {
+	t.Helper()
+
+	classroom := NewClassroom(assignment)
+	if err := classroom.Listen("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- classroom.Serve() }()
+	t.Cleanup(func() {
+		classroom.Close()
+		if err := <-served; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return classroom
+}
+
+// receiveAll reads the client's messages in the background, the way the
+// student's UI does while the game goes on.
+func receiveAll(client *Client) <-chan Message {
+	messages := make(chan Message, 16)
+	go func() {
+		defer close(messages)
+		for {
+			msg, err := client.Receive()
+			if err != nil {
+				return
+			}
+			messages <- msg
+		}
+	}()
+	return messages
+}
+
+// next waits for the next message of type want.
+func next(t *testing.T, messages <-chan Message, want MessageType) Message {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				t.Fatalf("connection closed waiting for %s", want)
+			}
+			if msg.Type == want {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
+
+func TestClassroomNewRound(t *testing.T) {
+	classroom := startClassroom(t, Assignment{Text: "the quick brown fox"})
+	ada := dial(t, classroom.Addr(), "ada")
+	messages := receiveAll(ada)
+
+	classroom.Start()
+	first := next(t, messages, MsgAssignment)
+	if err := ada.SendProgress(first.Round, 1, 2, 0.5, 30); err != nil {
+		t.Fatal(err)
+	}
+
+	// the next round starts while ada is still typing the first one, her
+	// late result must neither count for it nor for the new round
+	classroom.Start()
+	if err := ada.SendResult(first.Round, 80, 100, 4, 0, 3*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	second := next(t, messages, MsgAssignment)
+	if second.Round != first.Round+1 {
+		t.Fatalf("second assignment is round %d, want %d", second.Round, first.Round+1)
+	}
+	if err := ada.SendResult(second.Round, 40, 90, 3, 1, 6*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: the student's messages are handled in order, once the second
+	// result is in the first one was handled too
+	deadline := time.Now().Add(5 * time.Second)
+	for len(classroom.Results()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	results := classroom.Results()
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want only the second round's: %+v", len(results), results)
+	}
+	if result := results[0]; result.Round != second.Round || result.WPM != 40 || result.Elapsed != 6*time.Second {
+		t.Errorf("result = %+v, want round %d at 40 WPM in 6s", result, second.Round)
+	}
+}
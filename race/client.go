@@ -0,0 +1,94 @@
+package race
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+// Client is a racer's connection to a race server, or a student's to a
+// classroom.
+type Client struct {
+	ID   int    // assigned by the server
+	Name string // as accepted by the server
+
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+}
+
+// Dial joins the lobby of the server at addr under name.
+func Dial(addr, name string) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", addr, err)
+	}
+
+	c := &Client{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(conn)}
+	if err := c.enc.Encode(Message{Type: MsgJoin, Name: name}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not join: %w", err)
+	}
+
+	welcome, err := c.Receive()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if welcome.Type != MsgWelcome {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected %s message from server", welcome.Type)
+	}
+	c.ID = welcome.ID
+	c.Name = welcome.Name
+
+	return c, nil
+}
+
+// Watch connects to the broadcast at addr as a read-only watcher.
+func Watch(addr string) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", addr, err)
+	}
+	return &Client{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(conn)}, nil
+}
+
+// Receive waits for the next message from the server. Error messages are
+// returned as errors.
+func (c *Client) Receive() (Message, error) {
+	var msg Message
+	if err := c.dec.Decode(&msg); err != nil {
+		return msg, fmt.Errorf("lost connection to the server: %w", err)
+	}
+	if msg.Type == MsgError {
+		return msg, errors.New(msg.Error)
+	}
+	return msg, nil
+}
+
+// SendProgress reports the caret position and live WPM. round is the
+// classroom round being typed, 0 in races.
+func (c *Client) SendProgress(round, word, letter int, progress, wpm float64) error {
+	return c.enc.Encode(Message{Type: MsgProgress, Round: round, Word: word, Letter: letter, Progress: progress, WPM: wpm})
+}
+
+// SendFinish reports the final score.
+func (c *Client) SendFinish(wpm, accuracy float64) error {
+	return c.enc.Encode(Message{Type: MsgFinish, WPM: wpm, Accuracy: accuracy})
+}
+
+// SendResult reports the final score of a classroom round, elapsed being the
+// time the student took.
+func (c *Client) SendResult(round int, wpm, accuracy float64, correct, mistakes int, elapsed time.Duration) error {
+	return c.enc.Encode(Message{Type: MsgFinish, Round: round, WPM: wpm, Accuracy: accuracy, Correct: correct, Mistakes: mistakes, At: elapsed})
+}
+
+// Close leaves the race.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,102 @@
+// Package race runs typing races, classrooms and live game broadcasts over
+// the network.
+// Both sides exchange JSON messages, one per line, over a plain TCP
+// connection.
+package race
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// MessageType tells what a message is about.
+type MessageType string
+
+const (
+	MsgJoin      MessageType = "join"      // client: wants to race under Name
+	MsgWelcome   MessageType = "welcome"   // server: the racer's ID
+	MsgLobby     MessageType = "lobby"     // server: racers waiting for the race
+	MsgCountdown MessageType = "countdown" // server: the passage and seconds left before the start
+	MsgStart     MessageType = "start"     // server: go!
+	MsgProgress  MessageType = "progress"  // client: caret position and live WPM
+	MsgFinish    MessageType = "finish"    // client: final WPM and accuracy
+	MsgStandings MessageType = "standings" // server: everyone's progress
+	MsgResults   MessageType = "results"   // server: final rankings, the race is over
+	MsgError     MessageType = "error"     // server: the request was refused
+
+	MsgSession MessageType = "session" // broadcast: a new game started with Text
+	MsgKey     MessageType = "key"     // broadcast: a keystroke of the game
+	MsgEnd     MessageType = "end"     // broadcast: the game is over
+
+	MsgAssignment MessageType = "assignment" // classroom: the passage and constraints of a new round
+)
+
+// Message is a single line of the protocol. Only the fields that matter for
+// its type are set.
+type Message struct {
+	Type     MessageType `json:"type"`
+	ID       int         `json:"id,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	Text     string      `json:"text,omitempty"`
+	Seconds  int         `json:"seconds,omitempty"`
+	Word     int         `json:"word,omitempty"`     // index of the word the caret is on
+	Letter   int         `json:"letter,omitempty"`   // letters typed into that word
+	Progress float64     `json:"progress,omitempty"` // share of the passage typed, 0 to 1
+	WPM      float64     `json:"wpm,omitempty"`
+	Accuracy float64     `json:"accuracy,omitempty"`
+	Racers   []Racer     `json:"racers,omitempty"`
+	Error    string      `json:"error,omitempty"`
+
+	// Fields of a broadcast session
+	Action          string        `json:"action,omitempty"` // what a keystroke did (type, backspace, delete_word)
+	At              time.Duration `json:"at,omitempty"`     // time since the first key
+	Mode            string        `json:"mode,omitempty"`
+	ErrorPolicy     string        `json:"error_policy,omitempty"`
+	BackspacePolicy string        `json:"backspace_policy,omitempty"`
+
+	// Fields of a classroom
+	Round     int `json:"round,omitempty"`
+	TimeLimit int `json:"time_limit,omitempty"` // seconds, 0 for none
+	Correct   int `json:"correct,omitempty"`    // correct words of a finished game
+	Mistakes  int `json:"mistakes,omitempty"`   // wrong words of a finished game
+}
+
+// Racer is the state of one player as the server sees it.
+type Racer struct {
+	ID       int     `json:"id"`
+	Name     string  `json:"name"`
+	Word     int     `json:"word"`
+	Letter   int     `json:"letter"`
+	Progress float64 `json:"progress"`
+	WPM      float64 `json:"wpm"`
+	Accuracy float64 `json:"accuracy,omitempty"`
+	Finished bool    `json:"finished,omitempty"`
+	Place    int     `json:"place,omitempty"` // finishing place, 0 until finished
+	Left     bool    `json:"left,omitempty"`  // disconnected during the race
+}
+
+// Rank sorts racers for the standings: finished racers by place, then
+// everyone else by progress, racers who left last.
+func Rank(racers []Racer) []Racer {
+	ranked := slices.Clone(racers)
+	slices.SortStableFunc(ranked, func(a, b Racer) int {
+		if a.Left != b.Left {
+			if a.Left {
+				return 1
+			}
+			return -1
+		}
+		if a.Finished != b.Finished {
+			if a.Finished {
+				return -1
+			}
+			return 1
+		}
+		if a.Finished {
+			return cmp.Compare(a.Place, b.Place)
+		}
+		return cmp.Compare(b.Progress, a.Progress)
+	})
+	return ranked
+}
@@ -0,0 +1,365 @@
+package race
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type phase int
+
+const (
+	phaseLobby phase = iota
+	phaseCountdown
+	phaseRacing
+	phaseDone
+)
+
+// Server hosts a single race: racers join the lobby, the countdown starts
+// once enough of them are there and everyone types the same passage.
+type Server struct {
+	Text      string                           // passage everyone types
+	Players   int                              // racers needed before the countdown starts
+	Countdown int                              // seconds counted down before the start
+	Logf      func(format string, args ...any) // lobby and race events, nil to stay quiet
+
+	listener net.Listener
+	mu       sync.Mutex
+	clients  map[int]*client
+	nextID   int
+	phase    phase
+	places   int
+	done     chan struct{}
+}
+
+type client struct {
+	conn   net.Conn
+	racer  Racer
+	mu     sync.Mutex   // guards out and closed, messages are queued from several goroutines
+	out    chan Message // messages waiting to be written
+	closed bool
+}
+
+// newClient starts writing the messages queued for conn.
+func newClient(conn net.Conn) *client {
+	c := &client{conn: conn, out: make(chan Message, clientBuffer)}
+	go c.write()
+	return c
+}
+
+// write sends the queued messages and hangs up once the client is closed.
+func (c *client) write() {
+	defer c.conn.Close()
+	enc := json.NewEncoder(c.conn)
+	for msg := range c.out {
+		c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := enc.Encode(msg); err != nil {
+			return
+		}
+	}
+}
+
+// send queues msg without waiting on the network. A client too far behind
+// is disconnected.
+func (c *client) send(msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return errors.New("connection closed")
+	}
+	select {
+	case c.out <- msg:
+		return nil
+	default:
+		c.conn.Close()
+		return errors.New("too far behind, disconnected")
+	}
+}
+
+// close hangs up once the queued messages are written.
+func (c *client) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.out)
+	}
+}
+
+const (
+	writeTimeout = 5 * time.Second
+	maxNameRunes = 20
+
+	// clientBuffer is how many messages a racer or student may lag behind
+	// before they are dropped, a stalled connection never holds up the rest.
+	clientBuffer = 256
+)
+
+// NewServer returns a server for a race over text that starts once players
+// racers have joined.
+func NewServer(text string, players int) *Server {
+	return &Server{
+		Text:      text,
+		Players:   max(players, 1),
+		Countdown: 3,
+		clients:   make(map[int]*client),
+		done:      make(chan struct{}),
+	}
+}
+
+// Listen opens the server's TCP port. Use ":0" or "127.0.0.1:0" to pick a
+// free port, Addr tells which one.
+func (s *Server) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %w", addr, err)
+	}
+	s.listener = listener
+	return nil
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Serve accepts racers until the race is over and the results are sent.
+func (s *Server) Serve() error {
+	if s.listener == nil {
+		return errors.New("server is not listening")
+	}
+
+	go func() {
+		<-s.done
+		s.listener.Close()
+	}()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.done:
+				return nil
+			default:
+				return fmt.Errorf("could not accept racer: %w", err)
+			}
+		}
+		go s.handle(conn)
+	}
+}
+
+// Close ends the race early and disconnects everyone.
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finish()
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	c := newClient(conn)
+	defer c.close()
+
+	dec := json.NewDecoder(conn)
+
+	var join Message
+	if err := dec.Decode(&join); err != nil || join.Type != MsgJoin {
+		c.send(Message{Type: MsgError, Error: "expected a join message"})
+		return
+	}
+
+	if !s.join(c, join.Name) {
+		return
+	}
+	defer s.leave(c)
+
+	for {
+		var msg Message
+		if err := dec.Decode(&msg); err != nil {
+			return
+		}
+		s.receive(c, msg)
+	}
+}
+
+func (s *Server) join(c *client, name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.phase != phaseLobby {
+		c.send(Message{Type: MsgError, Error: "the race has already started"})
+		return false
+	}
+
+	s.nextID++
+	c.racer = Racer{ID: s.nextID, Name: cleanName(name, s.nextID)}
+	s.clients[c.racer.ID] = c
+	s.logf("%s joined the lobby (%d/%d)\n", c.racer.Name, len(s.clients), s.Players)
+
+	c.send(Message{Type: MsgWelcome, ID: c.racer.ID, Name: c.racer.Name})
+	s.broadcast(Message{Type: MsgLobby, Racers: s.racers()})
+
+	if len(s.clients) >= s.Players {
+		s.phase = phaseCountdown
+		go s.countdown()
+	}
+	return true
+}
+
+func (s *Server) leave(c *client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[c.racer.ID]; !ok || s.phase == phaseDone {
+		return
+	}
+	s.logf("%s left\n", c.racer.Name)
+
+	if s.phase == phaseLobby {
+		delete(s.clients, c.racer.ID)
+		s.broadcast(Message{Type: MsgLobby, Racers: s.racers()})
+		return
+	}
+
+	c.racer.Left = true
+	s.broadcast(Message{Type: MsgStandings, Racers: s.racers()})
+	s.checkDone()
+}
+
+func (s *Server) receive(c *client, msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.phase != phaseRacing || c.racer.Finished {
+		return
+	}
+
+	switch msg.Type {
+	case MsgProgress:
+		c.racer.Word = msg.Word
+		c.racer.Letter = msg.Letter
+		c.racer.Progress = clamp(msg.Progress)
+		c.racer.WPM = msg.WPM
+	case MsgFinish:
+		s.places++
+		c.racer.Finished = true
+		c.racer.Place = s.places
+		c.racer.Progress = 1
+		c.racer.WPM = msg.WPM
+		c.racer.Accuracy = msg.Accuracy
+		s.logf("%s finished #%d with %.1f WPM (%.1f%% accuracy)\n", c.racer.Name, c.racer.Place, msg.WPM, msg.Accuracy)
+	default:
+		return
+	}
+
+	s.broadcast(Message{Type: MsgStandings, Racers: s.racers()})
+	s.checkDone()
+}
+
+// countdown sends the passage, counts down and starts the race.
+func (s *Server) countdown() {
+	s.mu.Lock()
+	s.logf("Starting the race with %d racers\n", len(s.clients))
+	s.broadcast(Message{Type: MsgCountdown, Text: s.Text, Seconds: s.Countdown, Racers: s.racers()})
+	s.mu.Unlock()
+
+	for seconds := s.Countdown - 1; seconds > 0; seconds-- {
+		time.Sleep(time.Second)
+		s.mu.Lock()
+		s.broadcast(Message{Type: MsgCountdown, Seconds: seconds})
+		s.mu.Unlock()
+	}
+	time.Sleep(time.Second)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.phase != phaseCountdown {
+		return
+	}
+	s.phase = phaseRacing
+	s.broadcast(Message{Type: MsgStart})
+	s.checkDone()
+}
+
+// checkDone sends the results once every racer still connected finished.
+func (s *Server) checkDone() {
+	if s.phase != phaseRacing {
+		return
+	}
+	for _, c := range s.clients {
+		if !c.racer.Finished && !c.racer.Left {
+			return
+		}
+	}
+	s.broadcast(Message{Type: MsgResults, Racers: s.racers()})
+	s.logf("Race over\n")
+	s.finish()
+}
+
+func (s *Server) finish() {
+	if s.phase == phaseDone {
+		return
+	}
+	s.phase = phaseDone
+	close(s.done)
+	for _, c := range s.clients {
+		c.close()
+	}
+}
+
+// broadcast sends msg to every connected racer. The caller holds s.mu.
+func (s *Server) broadcast(msg Message) {
+	for _, c := range s.clients {
+		if c.racer.Left {
+			continue
+		}
+		if err := c.send(msg); err != nil {
+			s.logf("Could not reach %s: %v\n", c.racer.Name, err)
+		}
+	}
+}
+
+// racers returns the ranked racers. The caller holds s.mu.
+func (s *Server) racers() []Racer {
+	racers := make([]Racer, 0, len(s.clients))
+	for _, c := range s.clients {
+		racers = append(racers, c.racer)
+	}
+	// NOTE: map order is random, keep racers with equal progress in join order
+	slices.SortFunc(racers, func(a, b Racer) int { return cmp.Compare(a.ID, b.ID) })
+	return Rank(racers)
+}
+
+func cleanName(name string, id int) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if runes := []rune(name); len(runes) > maxNameRunes {
+		name = string(runes[:maxNameRunes])
+	}
+	if name == "" {
+		name = fmt.Sprintf("Racer %d", id)
+	}
+	return name
+}
+
+func clamp(progress float64) float64 {
+	if progress < 0 {
+		return 0
+	}
+	if progress > 1 {
+		return 1
+	}
+	return progress
+}
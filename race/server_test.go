@@ -0,0 +1,212 @@
+package race
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+// startServer runs a race server on a free loopback port.
+func startServer(t *testing.T, text string, players int) *Server {
+	t.Helper()
+
+	server := NewServer(text, players)
+	server.Countdown = 1
+	if err := server.Listen("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- server.Serve() }()
+	t.Cleanup(func() {
+		server.Close()
+		if err := <-served; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return server
+}
+
+// dial joins the server or classroom at addr under name.
+func dial(t *testing.T, addr net.Addr, name string) *Client {
+	t.Helper()
+
+	client, err := Dial(addr.String(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// waitFor reads messages until one of type want arrives.
+func waitFor(t *testing.T, client *Client, want MessageType) Message {
+	t.Helper()
+
+	client.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		msg, err := client.Receive()
+		if err != nil {
+			t.Fatalf("%s waiting for %s: %v", client.Name, want, err)
+		}
+		if msg.Type == want {
+			return msg
+		}
+	}
+}
+
+func TestServerRace(t *testing.T) {
+	const text = "the quick brown fox"
+	server := startServer(t, text, 2)
+
+	ada := dial(t, server.Addr(), "ada")
+	linus := dial(t, server.Addr(), "  linus  ")
+	if ada.ID == linus.ID {
+		t.Fatalf("both racers got ID %d", ada.ID)
+	}
+	if linus.Name != "linus" {
+		t.Errorf("name = %q, want it trimmed to %q", linus.Name, "linus")
+	}
+
+	for _, client := range []*Client{ada, linus} {
+		countdown := waitFor(t, client, MsgCountdown)
+		if countdown.Text != text {
+			t.Errorf("%s got passage %q, want %q", client.Name, countdown.Text, text)
+		}
+		if len(countdown.Racers) != 2 {
+			t.Errorf("%s sees %d racers in the countdown, want 2", client.Name, len(countdown.Racers))
+		}
+		waitFor(t, client, MsgStart)
+	}
+
+	if late, err := Dial(server.Addr().String(), "late"); err == nil {
+		late.Close()
+		t.Error("joined a race that already started")
+	}
+
+	if err := ada.SendProgress(0, 2, 1, 0.5, 40); err != nil {
+		t.Fatal(err)
+	}
+	standings := waitFor(t, linus, MsgStandings)
+	if leader := standings.Racers[0]; leader.ID != ada.ID || leader.Progress != 0.5 || leader.WPM != 40 {
+		t.Errorf("leader = %+v, want ada at half way with 40 WPM", leader)
+	}
+
+	if err := linus.SendFinish(55, 97); err != nil {
+		t.Fatal(err)
+	}
+	// NOTE: each racer has its own connection, let linus' finish land first
+	waitFor(t, linus, MsgStandings)
+	if err := ada.SendFinish(42, 99); err != nil {
+		t.Fatal(err)
+	}
+
+	results := waitFor(t, ada, MsgResults)
+	tests := []struct {
+		id    int
+		place int
+		wpm   float64
+	}{
+		{linus.ID, 1, 55},
+		{ada.ID, 2, 42},
+	}
+	if len(results.Racers) != len(tests) {
+		t.Fatalf("got %d racers in the results, want %d", len(results.Racers), len(tests))
+	}
+	for i, tt := range tests {
+		racer := results.Racers[i]
+		if racer.ID != tt.id || racer.Place != tt.place || racer.WPM != tt.wpm || !racer.Finished {
+			t.Errorf("results[%d] = %+v, want ID %d in place %d with %.0f WPM", i, racer, tt.id, tt.place, tt.wpm)
+		}
+	}
+}
+
+func TestServerRacerLeaves(t *testing.T) {
+	server := startServer(t, "a b c", 2)
+
+	ada := dial(t, server.Addr(), "ada")
+	linus := dial(t, server.Addr(), "linus")
+	waitFor(t, ada, MsgStart)
+	waitFor(t, linus, MsgStart)
+
+	linus.Close()
+	if err := ada.SendFinish(30, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	results := waitFor(t, ada, MsgResults)
+	if len(results.Racers) != 2 {
+		t.Fatalf("got %d racers in the results, want 2", len(results.Racers))
+	}
+	if winner := results.Racers[0]; winner.ID != ada.ID || winner.Place != 1 {
+		t.Errorf("winner = %+v, want ada", winner)
+	}
+	if last := results.Racers[1]; !last.Left {
+		t.Errorf("last = %+v, want linus marked as left", last)
+	}
+}
+
+func TestStalledRacer(t *testing.T) {
+	server := NewServer("the quick brown fox", 2)
+
+	stalledConn, stalledPeer := net.Pipe() // nobody reads stalledPeer
+	defer stalledPeer.Close()
+	readingConn, readingPeer := net.Pipe()
+	stalled, reading := newClient(stalledConn), newClient(readingConn)
+	server.clients[1], server.clients[2] = stalled, reading
+
+	received := make(chan int)
+	go func() {
+		dec := json.NewDecoder(readingPeer)
+		count := 0
+		for {
+			var msg Message
+			if err := dec.Decode(&msg); err != nil {
+				break
+			}
+			count++
+		}
+		received <- count
+	}()
+
+	const sent = clientBuffer
+	start := time.Now()
+	server.mu.Lock()
+	for range sent {
+		server.broadcast(Message{Type: MsgStandings})
+	}
+	server.mu.Unlock()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("the stalled racer held up the race for %v", elapsed)
+	}
+
+	// one message is stuck in the pipe, the queue behind it is full
+	stalled.send(Message{Type: MsgStandings})
+	if err := stalled.send(Message{Type: MsgStandings}); err == nil {
+		t.Error("messages still queue up for the stalled racer")
+	}
+	reading.close()
+	if count := <-received; count != sent {
+		t.Errorf("the other racer got %d messages, want %d", count, sent)
+	}
+}
+
+func TestCleanName(t *testing.T) {
+	tests := []struct {
+		name string
+		id   int
+		want string
+	}{
+		{"ada", 1, "ada"},
+		{"  ada   lovelace ", 1, "ada lovelace"},
+		{"", 3, "Racer 3"},
+		{"\t\n", 4, "Racer 4"},
+		{"abcdefghijklmnopqrstuvwxyz", 1, "abcdefghijklmnopqrst"},
+	}
+	for _, tt := range tests {
+		if got := cleanName(tt.name, tt.id); got != tt.want {
+			t.Errorf("cleanName(%q, %d) = %q, want %q", tt.name, tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/text/unicode/bidi"
+)
+
+type TextDirection string
+
+const (
+	DirectionAuto TextDirection = ""    // Detect from the text
+	DirectionLTR  TextDirection = "ltr" // Left to right (latin, cyrillic, ...)
+	DirectionRTL  TextDirection = "rtl" // Right to left (arabic, hebrew, ...)
+)
+
+// DetectDirection returns the direction of the first strong character in
+// text (rule P2 of the unicode bidi algorithm), or DirectionAuto when the
+// text has no strong characters at all.
+func DetectDirection(text string) TextDirection {
+	for _, r := range text {
+		props, _ := bidi.LookupRune(r)
+		switch props.Class() {
+		case bidi.L:
+			return DirectionLTR
+		case bidi.R, bidi.AL:
+			return DirectionRTL
+		}
+	}
+	return DirectionAuto
+}
+
+// isLTRWord reports whether a word keeps left to right order inside a right
+// to left paragraph: latin words and numbers ("2025", "3.14").
+func isLTRWord(word string) bool {
+	switch DetectDirection(word) {
+	case DirectionLTR:
+		return true
+	case DirectionRTL:
+		return false
+	}
+	return strings.IndexFunc(word, unicode.IsDigit) >= 0
+}
+
+// mirrorGlyph swaps paired brackets so "(" reads as an opening bracket when
+// drawn right to left.
+func mirrorGlyph(g string) string {
+	if len([]rune(g)) != 1 {
+		return g
+	}
+	return bidi.ReverseString(g)
+}
+
+// renderRTL lays out a right to left paragraph. Terminals draw cells left to
+// right, so the words are wrapped here, each line is reversed and right
+// aligned. Runs of left to right words (latin, numbers) keep their order.
+func (t *Text) renderRTL(showCursor bool) string {
+	width := TextContainerStyle.GetWidth() - TextContainerStyle.GetHorizontalPadding()
+	lineStyle := lipgloss.NewStyle().Width(width).Align(lipgloss.Right)
+
+	var lines []string
+	var line []renderedWord
+	lineWidth := 0
+
+	for i, word := range t.words {
+		rendered := renderedWord{word: word, text: t.renderWord(i, showCursor)}
+		wordWidth := lipgloss.Width(rendered.text)
+		if !word.IsSpace() && lineWidth > 0 && lineWidth+wordWidth > width {
+			lines = append(lines, lineStyle.Render(renderRTLLine(line)))
+			line = nil
+			lineWidth = 0
+		}
+		line = append(line, rendered)
+		lineWidth += wordWidth
+	}
+	if len(line) > 0 {
+		lines = append(lines, lineStyle.Render(renderRTLLine(line)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type renderedWord struct {
+	word *Word
+	text string
+}
+
+func renderRTLLine(line []renderedWord) string {
+	visual := slices.Clone(line)
+	slices.Reverse(visual)
+
+	// NOTE: reversing the line also reversed runs of LTR words, flip them back
+	// together with the single spaces between them
+	ltrWord := func(i int) bool {
+		return i >= 0 && i < len(visual) && !visual[i].word.IsSpace() && !visual[i].word.rtl
+	}
+	inRun := func(i int) bool {
+		if visual[i].word.IsSpace() {
+			return ltrWord(i-1) && ltrWord(i+1)
+		}
+		return ltrWord(i)
+	}
+
+	for start := 0; start < len(visual); {
+		if !ltrWord(start) {
+			start++
+			continue
+		}
+		end := start
+		for end+1 < len(visual) && inRun(end+1) {
+			end++
+		}
+		slices.Reverse(visual[start : end+1])
+		start = end + 1
+	}
+
+	var result strings.Builder
+	for _, rendered := range visual {
+		result.WriteString(rendered.text)
+	}
+	return result.String()
+}
@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/prime-run/go-typer/race"
+)
+
+// BotProfile is how a bot racer types.
+type BotProfile struct {
+	Name           string
+	WPM            float64 // average speed
+	Variability    float64 // spread of the time between keys, as a share of it (0.2 is about ±20%)
+	ErrorRate      float64 // chance a letter is mistyped, 0 to 1
+	CorrectionRate float64 // chance a mistyped letter is noticed and fixed, 0 to 1
+}
+
+// BotOpponents race against the player in every game started from the
+// start screen or `go-typer start`, none by default.
+var BotOpponents []BotProfile
+
+// BotSeed seeds the bots' typing when BotSeeded is set, otherwise the seed is
+// random. With a fixed seed bots type the same way every game, keystroke for
+// keystroke.
+var (
+	BotSeed   int64
+	BotSeeded bool
+)
+
+const (
+	botMinInterval  = 10 * time.Millisecond
+	botNoticeDelay  = 3 // keys' worth of time a bot takes to notice a typo
+	botLetterPerWPM = 5 // letters per word, as in the WPM formula
+)
+
+// Bot types a passage on its own Text, driven by the game's clock.
+type Bot struct {
+	Profile BotProfile
+
+	text     *Text
+	rng      *rand.Rand
+	next     time.Duration // when the next key is due
+	fixing   bool          // the last letter was a typo that will be fixed
+	elapsed  time.Duration
+	finished time.Duration // when the bot typed the last key, 0 until then
+}
+
+// NewBot returns a bot racing on text.
+func NewBot(profile BotProfile, text string, seed int64) *Bot {
+	b := &Bot{
+		Profile: profile,
+		text:    NewText(text),
+		rng:     rand.New(rand.NewSource(seed)),
+	}
+	b.next = b.interval()
+	return b
+}
+
+// newBots creates the BotOpponents for a game on text.
+func newBots(text string) []*Bot {
+	if len(BotOpponents) == 0 {
+		return nil
+	}
+
+	seed := BotSeed
+	if !BotSeeded {
+		seed = time.Now().UnixNano()
+	}
+
+	bots := make([]*Bot, len(BotOpponents))
+	for i, profile := range BotOpponents {
+		if profile.Name == "" {
+			profile.Name = fmt.Sprintf("Bot %d (%.0f WPM)", i+1, profile.WPM)
+		}
+		bots[i] = NewBot(profile, text, seed+int64(i))
+	}
+	return bots
+}
+
+// interval is the time until the next key, around the bot's target speed.
+func (b *Bot) interval() time.Duration {
+	wpm := b.Profile.WPM
+	if wpm < 1 {
+		wpm = 1
+	}
+	mean := float64(time.Minute) / (wpm * botLetterPerWPM)
+	interval := time.Duration(mean * (1 + b.Profile.Variability*b.rng.NormFloat64()))
+	if interval < botMinInterval {
+		return botMinInterval
+	}
+	return interval
+}
+
+// Advance types every key due by elapsed, the time since the game started.
+func (b *Bot) Advance(elapsed time.Duration) {
+	b.elapsed = elapsed
+	for !b.Finished() && b.next <= elapsed {
+		b.step()
+		if b.text.Finished() {
+			b.finished = b.next
+			return
+		}
+		b.next += b.interval()
+		if b.fixing {
+			b.next += botNoticeDelay * b.interval()
+		}
+	}
+}
+
+// step types a single key.
+func (b *Bot) step() {
+	if b.fixing {
+		b.fixing = false
+		b.text.Backspace()
+		return
+	}
+
+	g := b.text.NextGrapheme()
+	if g != " " && b.rng.Float64() < b.Profile.ErrorRate {
+		b.text.Type(typo(g, b.rng))
+		b.fixing = b.rng.Float64() < b.Profile.CorrectionRate
+		return
+	}
+	b.text.Type(g)
+}
+
+// typo returns a letter that isn't g.
+func typo(g string, rng *rand.Rand) string {
+	for {
+		letter := string(rune('a' + rng.Intn(26)))
+		if letter != g {
+			return letter
+		}
+	}
+}
+
+// Finished reports whether the bot typed the whole passage.
+func (b *Bot) Finished() bool {
+	return b.finished > 0
+}
+
+// Caret returns where the bot is in the passage.
+func (b *Bot) Caret() Caret {
+	return b.text.Caret()
+}
+
+// Score is the bot's WPM and accuracy so far, or final once it finished.
+func (b *Bot) Score() GameResult {
+	state := GameState{Text: b.text, Started: true, Elapsed: b.elapsed}
+	if b.Finished() {
+		state.Elapsed = b.finished
+		return scoreText(state)
+	}
+	total, correct, errors := b.text.TypedStats()
+	return scoreWords(total, correct, errors, state.Elapsed)
+}
+
+// botCarets returns where the bots are in the passage.
+func botCarets(bots []*Bot) []Caret {
+	carets := make([]Caret, 0, len(bots))
+	for _, bot := range bots {
+		if !bot.Finished() {
+			carets = append(carets, bot.Caret())
+		}
+	}
+	return carets
+}
+
+// botStandings ranks the player, racer ID 0, against the bots. A finished
+// player is placed at elapsed.
+func botStandings(bots []*Bot, player race.Racer, elapsed time.Duration) []race.Racer {
+	type finish struct {
+		id int
+		at time.Duration
+	}
+	var finishes []finish
+	if player.Finished {
+		finishes = append(finishes, finish{0, elapsed})
+	}
+
+	racers := []race.Racer{player}
+	for i, bot := range bots {
+		score := bot.Score()
+		racers = append(racers, race.Racer{
+			ID:       i + 1,
+			Name:     bot.Profile.Name,
+			Progress: bot.text.Progress(),
+			WPM:      score.WPM,
+			Accuracy: score.Accuracy,
+			Finished: bot.Finished(),
+		})
+		if bot.Finished() {
+			finishes = append(finishes, finish{i + 1, bot.finished})
+		}
+	}
+
+	// NOTE: stable, so the player wins a tie with a bot
+	slices.SortStableFunc(finishes, func(a, b finish) int { return cmp.Compare(a.at, b.at) })
+	for place, f := range finishes {
+		racers[f.id].Place = place + 1
+	}
+	return race.Rank(racers)
+}
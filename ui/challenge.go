@@ -0,0 +1,300 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Challenge is a typing test with pass criteria, written in YAML so training
+// packs can be kept in a repository. The passage is the text, the file or,
+// with neither, a text of the game mode. Only the challenge's own transforms
+// apply to it, never the player's settings, so everyone types the same text.
+type Challenge struct {
+	Name            string   `yaml:"name"`
+	Description     string   `yaml:"description,omitempty"`
+	Text            string   `yaml:"text,omitempty"`
+	File            string   `yaml:"file,omitempty"`             // relative to the challenge file
+	Mode            string   `yaml:"mode,omitempty"`             // game mode, normal by default
+	Language        string   `yaml:"language,omitempty"`         // language pack for generated text
+	Words           int      `yaml:"words,omitempty"`            // words in words mode
+	Seed            *int64   `yaml:"seed,omitempty"`             // makes generated text the same every run, 0 included
+	Pipeline        []string `yaml:"pipeline,omitempty"`         // extra text transforms, see Text Pipelines
+	ErrorPolicy     string   `yaml:"error_policy,omitempty"`     // free, stop_on_letter or stop_on_word
+	BackspacePolicy string   `yaml:"backspace_policy,omitempty"` // free, word or off
+	TimeLimit       int      `yaml:"time_limit,omitempty"`       // seconds, 0 for none
+	MinWPM          float64  `yaml:"min_wpm,omitempty"`
+	MinAccuracy     float64  `yaml:"min_accuracy,omitempty"` // percent
+}
+
+// LoadChallenge reads and checks a challenge file. A passage in a separate
+// file is read into Text.
+func LoadChallenge(path string) (*Challenge, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading challenge: %w", err)
+	}
+
+	var c Challenge
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("error parsing challenge %s: %w", path, err)
+	}
+
+	if c.Name == "" {
+		c.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	if c.File != "" {
+		if c.Text != "" {
+			return nil, fmt.Errorf("challenge %s has both text and file, pick one", path)
+		}
+		file := c.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading challenge text: %w", err)
+		}
+		c.Text = string(text)
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("challenge %s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+func (c *Challenge) validate() error {
+	c.Mode = strings.ToLower(c.Mode)
+	if c.Mode == "" {
+		c.Mode = GameModeNormal
+	}
+	if !slices.Contains(GameModeNames(), c.Mode) {
+		return fmt.Errorf("unknown game mode %q (available: %s)", c.Mode, strings.Join(GameModeNames(), ", "))
+	}
+
+	c.ErrorPolicy = strings.ToLower(c.ErrorPolicy)
+	if c.ErrorPolicy != "" && string(ParseErrorPolicy(c.ErrorPolicy)) != c.ErrorPolicy {
+		return fmt.Errorf("unknown error policy %q (available: free, stop_on_letter, stop_on_word)", c.ErrorPolicy)
+	}
+	c.BackspacePolicy = strings.ToLower(c.BackspacePolicy)
+	if c.BackspacePolicy != "" && string(ParseBackspacePolicy(c.BackspacePolicy)) != c.BackspacePolicy {
+		return fmt.Errorf("unknown backspace policy %q (available: free, word, off)", c.BackspacePolicy)
+	}
+
+	if c.Language != "" {
+		if _, err := LoadLanguagePack(c.Language); err != nil {
+			return err
+		}
+	}
+
+	if _, err := utils.ParsePipeline(c.Pipeline); err != nil {
+		return err
+	}
+
+	switch {
+	case c.TimeLimit < 0:
+		return errors.New("time_limit can't be negative")
+	case c.Words < 0:
+		return errors.New("words can't be negative")
+	case c.MinWPM < 0:
+		return errors.New("min_wpm can't be negative")
+	case c.MinAccuracy < 0 || c.MinAccuracy > 100:
+		return errors.New("min_accuracy must be between 0 and 100")
+	case c.Mode == GameModeTimed && c.Text != "":
+		return errors.New("timed mode generates its own words, drop the text or pick another mode")
+	}
+
+	return nil
+}
+
+// Passes reports whether a result meets the challenge's criteria.
+func (c *Challenge) Passes(wpm, accuracy float64) bool {
+	return wpm >= c.MinWPM && accuracy >= c.MinAccuracy
+}
+
+// Criteria describes what it takes to pass.
+func (c *Challenge) Criteria() string {
+	var criteria []string
+	if c.MinWPM > 0 {
+		criteria = append(criteria, fmt.Sprintf("%.0f WPM", c.MinWPM))
+	}
+	if c.MinAccuracy > 0 {
+		criteria = append(criteria, fmt.Sprintf("%.0f%% accuracy", c.MinAccuracy))
+	}
+	if len(criteria) == 0 {
+		return "finish to pass"
+	}
+	return "needs " + strings.Join(criteria, " and ")
+}
+
+// apply puts the challenge's rules into the current settings for this run,
+// nothing is saved.
+func (c *Challenge) apply() {
+	CurrentSettings.GameMode = c.Mode
+	if c.Language != "" {
+		CurrentSettings.Language = c.Language
+	}
+	if c.Words > 0 {
+		CurrentSettings.WordCount = c.Words
+	}
+	if c.ErrorPolicy != "" {
+		CurrentSettings.ErrorPolicy = c.ErrorPolicy
+	}
+	if c.BackspacePolicy != "" {
+		CurrentSettings.BackspacePolicy = c.BackspacePolicy
+	}
+	if c.Mode == GameModeTimed && c.TimeLimit > 0 {
+		CurrentSettings.TimeLimit = c.TimeLimit
+	}
+	if c.Seed != nil {
+		utils.SeedRandom(*c.Seed)
+	}
+}
+
+// textSettings are the settings the passage is generated with: the defaults
+// plus what the challenge sets, without any injection.
+func (c *Challenge) textSettings() UserSettings {
+	settings := DefaultSettings
+	settings.GameMode = c.Mode
+	settings.UseNumbers = false
+	settings.UsePunctuation = false
+	if c.Language != "" {
+		settings.Language = c.Language
+	}
+	if c.Words > 0 {
+		settings.WordCount = c.Words
+	}
+	if c.TimeLimit > 0 {
+		settings.TimeLimit = c.TimeLimit
+	}
+	return settings
+}
+
+// passage returns the text of the challenge: the given text cleaned up or a
+// generated one, then the challenge's own transforms.
+func (c *Challenge) passage() string {
+	text := utils.FormatText(c.Text)
+	if c.Text == "" {
+		text = LookupGameMode(c.Mode).GenerateText(c.textSettings())
+	}
+
+	// NOTE: checked by validate
+	pipeline, _ := utils.ParsePipeline(c.Pipeline)
+	return pipeline.Apply(text)
+}
+
+// challengeMode plays a challenge in its game mode, adding the time limit
+// for modes that don't have their own.
+type challengeMode struct {
+	GameMode
+	challenge *Challenge
+	limit     time.Duration // 0 for none
+	passed    *bool         // outcome of the last finished attempt, shared by retries
+}
+
+func newChallengeMode(c *Challenge) *challengeMode {
+	mode := &challengeMode{GameMode: ActiveGameMode(), challenge: c, passed: new(bool)}
+	if c.Mode != GameModeTimed {
+		mode.limit = time.Duration(c.TimeLimit) * time.Second
+	}
+	return mode
+}
+
+func (m *challengeMode) IsComplete(state GameState) bool {
+	return (m.limit > 0 && state.Elapsed >= m.limit) || m.GameMode.IsComplete(state)
+}
+
+// Score only counts the words typed before the time ran out.
+func (m *challengeMode) Score(state GameState) GameResult {
+	if m.limit == 0 || state.Elapsed < m.limit {
+		return m.GameMode.Score(state)
+	}
+	total, correct, errors := state.Text.TypedStats()
+	return scoreWords(total, correct, errors, m.limit)
+}
+
+func (m *challengeMode) HUD(state GameState) string {
+	if m.limit == 0 {
+		return m.GameMode.HUD(state)
+	}
+	remaining := m.limit
+	if state.Started && state.Elapsed > 0 {
+		remaining -= state.Elapsed
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return TimerStyle.Render(formatDuration(remaining))
+}
+
+func (m *challengeMode) Finish(result GameResult, end *EndGameModel) {
+	*m.passed = m.challenge.Passes(result.WPM, result.Accuracy)
+	end.challenge = m.challenge
+	end.challengePassed = *m.passed
+}
+
+// newChallengeGame starts an attempt at a challenge on text.
+func newChallengeGame(width, height int, text string, mode *challengeMode) *TypingModel {
+	model := NewTypingModel(width, height, text)
+	model.mode = mode
+	model.bots = nil
+
+	banner := fmt.Sprintf("%s • %s", mode.challenge.Name, mode.challenge.Criteria())
+	if mode.limit > 0 {
+		banner += fmt.Sprintf(" • %ds", mode.challenge.TimeLimit)
+	}
+	model.banner = HelpStyle(banner)
+	return model
+}
+
+func (m *EndGameModel) selectChallengeOption() (tea.Model, tea.Cmd) {
+	mode, ok := m.mode.(*challengeMode)
+	if m.selectedItem != 0 || !ok {
+		return m, tea.Quit
+	}
+	return newChallengeGame(m.width, m.height, m.text, mode), InitGlobalTick()
+}
+
+func (m *EndGameModel) renderChallengeResult() string {
+	criteria := HelpStyle("(" + m.challenge.Criteria() + ")")
+
+	if m.challengePassed {
+		return lipgloss.NewStyle().
+			Foreground(GetColor("text_correct")).
+			Bold(true).
+			Render(m.challenge.Name+": passed!") + " " + criteria
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(GetColor("text_error")).
+		Bold(true).
+		Render(m.challenge.Name+": failed") + " " + criteria
+}
+
+// RunChallenge plays a challenge until the user quits and reports whether
+// the last finished attempt passed.
+func RunChallenge(c *Challenge) (bool, error) {
+	devlog.Log("Challenge: Starting %s", c.Name)
+
+	c.apply()
+	mode := newChallengeMode(c)
+
+	p := tea.NewProgram(newChallengeGame(0, 0, c.passage(), mode), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return false, fmt.Errorf("error running challenge: %w", err)
+	}
+	return *mode.passed, nil
+}
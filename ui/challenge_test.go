@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLoadChallenge(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		wantText string // empty to skip the check
+		wantErr  string // part of the error, empty for none
+	}{
+		{"text", "name: warmup\ntext: the quick brown fox\nmin_wpm: 40\n", "the quick brown fox", ""},
+		{"defaults", "text: hi\n", "", ""},
+		{"file next to the challenge", "file: passage.txt\n", "from the file\n", ""},
+		{"missing file", "file: missing.txt\n", "", "error reading challenge text"},
+		{"text and file", "text: hi\nfile: passage.txt\n", "", "both text and file"},
+		{"unknown mode", "mode: marathon\n", "", `unknown game mode "marathon"`},
+		{"mode case", "mode: Words\nwords: 10\n", "", ""},
+		{"unknown error policy", "error_policy: strict\n", "", `unknown error policy "strict"`},
+		{"unknown backspace policy", "backspace_policy: never\n", "", `unknown backspace policy "never"`},
+		{"known policies", "error_policy: stop_on_word\nbackspace_policy: word\n", "", ""},
+		{"unknown language", "language: xx\n", "", "language pack not found"},
+		{"bad pipeline", "pipeline: [shout]\n", "", `unknown text transform "shout"`},
+		{"negative time limit", "time_limit: -1\n", "", "time_limit can't be negative"},
+		{"negative words", "words: -1\n", "", "words can't be negative"},
+		{"negative wpm", "min_wpm: -5\n", "", "min_wpm can't be negative"},
+		{"accuracy over 100", "min_accuracy: 101\n", "", "min_accuracy must be between 0 and 100"},
+		{"timed with text", "mode: timed\ntext: hi\n", "", "timed mode generates its own words"},
+		{"not yaml", "text: [unclosed\n", "", "error parsing challenge"},
+	}
+
+	testConfigDir(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "passage.txt"), []byte("from the file\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("challenge%d.yml", i))
+			if err := os.WriteFile(path, []byte(tt.yaml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			c, err := LoadChallenge(path)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("error %v, want one about %q", err, tt.wantErr)
+			case err != nil:
+				return
+			}
+
+			if tt.wantText != "" && c.Text != tt.wantText {
+				t.Errorf("text %q, want %q", c.Text, tt.wantText)
+			}
+			if c.Mode == "" || strings.ToLower(c.Mode) != c.Mode {
+				t.Errorf("mode %q isn't normalized", c.Mode)
+			}
+		})
+	}
+}
+
+func TestChallengeDefaultName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "home-row.yml")
+	if err := os.WriteFile(path, []byte("text: asdf jkl\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadChallenge(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "home-row" || c.Mode != GameModeNormal {
+		t.Errorf("name %q and mode %q, want home-row and %s", c.Name, c.Mode, GameModeNormal)
+	}
+}
+
+func TestChallengePasses(t *testing.T) {
+	tests := []struct {
+		name         string
+		minWPM       float64
+		minAccuracy  float64
+		wpm          float64
+		accuracy     float64
+		want         bool
+		wantCriteria string
+	}{
+		{"no criteria", 0, 0, 0, 0, true, "finish to pass"},
+		{"both met", 50, 95, 60, 97, true, "needs 50 WPM and 95% accuracy"},
+		{"exactly met", 50, 95, 50, 95, true, "needs 50 WPM and 95% accuracy"},
+		{"too slow", 50, 95, 49.9, 100, false, "needs 50 WPM and 95% accuracy"},
+		{"too sloppy", 50, 95, 80, 94, false, "needs 50 WPM and 95% accuracy"},
+		{"speed only", 40, 0, 41, 10, true, "needs 40 WPM"},
+		{"accuracy only", 0, 100, 120, 99, false, "needs 100% accuracy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Challenge{MinWPM: tt.minWPM, MinAccuracy: tt.minAccuracy}
+			if got := c.Passes(tt.wpm, tt.accuracy); got != tt.want {
+				t.Errorf("Passes(%g, %g) = %v, want %v", tt.wpm, tt.accuracy, got, tt.want)
+			}
+			if got := c.Criteria(); got != tt.wantCriteria {
+				t.Errorf("Criteria() = %q, want %q", got, tt.wantCriteria)
+			}
+		})
+	}
+}
+
+func TestChallengePassage(t *testing.T) {
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+
+	// the player's own transforms never reach a challenge
+	CurrentSettings.Pipelines = map[string][]string{GameModeNormal: {"max_words:1"}}
+
+	tests := []struct {
+		name     string
+		pipeline []string
+		text     string
+		want     string
+	}{
+		{"cleaned up", nil, "  The quick\n\tbrown fox ", "The quick brown fox"},
+		{"with a pipeline", []string{"lowercase", "strip_punctuation", "collapse_whitespace"}, "Hello, World!", "hello world"},
+	}
+
+	for _, tt := range tests {
+		c := &Challenge{Mode: GameModeNormal, Text: tt.text, Pipeline: tt.pipeline}
+		if got := c.passage(); got != tt.want {
+			t.Errorf("%s: passage %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	words := &Challenge{Mode: GameModeWords, Words: 7, Pipeline: []string{"max_words:5"}}
+	if n := len(strings.Fields(words.passage())); n != 5 {
+		t.Errorf("generated passage has %d words, want 5", n)
+	}
+
+	timed := &Challenge{Mode: GameModeTimed, TimeLimit: 120}
+	if n := len(strings.Fields(timed.passage())); n < 400 {
+		t.Errorf("a 120s timed passage has %d words, too few for 200 WPM", n)
+	}
+}
+
+func TestChallengeAttempts(t *testing.T) {
+	c := &Challenge{Name: "warmup", Mode: GameModeNormal, MinWPM: 50, MinAccuracy: 90}
+	pass := GameResult{WPM: 60, Accuracy: 95}
+	fail := GameResult{WPM: 30, Accuracy: 95}
+
+	tests := []struct {
+		name     string
+		attempts []GameResult
+		want     bool // what the challenge command exits with
+	}{
+		{"no finished attempt", nil, false},
+		{"passed", []GameResult{pass}, true},
+		{"failed", []GameResult{fail}, false},
+		{"passed on a retry", []GameResult{fail, pass}, true},
+		{"failed the last retry", []GameResult{pass, fail}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := &challengeMode{GameMode: LookupGameMode(c.Mode), challenge: c, passed: new(bool)}
+			for i, result := range tt.attempts {
+				end := NewEndGameModel(result.WPM, result.Accuracy, 0, 0, 0, "the text")
+				end.mode = mode
+				mode.Finish(result, end)
+
+				if end.challenge != c || end.challengePassed != *mode.passed {
+					t.Fatalf("attempt %d: the end screen shows %v, the challenge has %v", i+1, end.challengePassed, *mode.passed)
+				}
+
+				// retries keep playing the same challenge
+				retry, _ := end.selectChallengeOption()
+				if game, ok := retry.(*TypingModel); !ok || game.mode != mode {
+					t.Fatalf("attempt %d: retry doesn't continue the challenge", i+1)
+				}
+			}
+			if *mode.passed != tt.want {
+				t.Errorf("passed = %v, want %v", *mode.passed, tt.want)
+			}
+		})
+	}
+}
+
+func TestChallengeTimeLimit(t *testing.T) {
+	text := NewText("one two three four")
+	typeKeys(text, "one two")
+	state := GameState{Text: text, Started: true, Elapsed: 10 * time.Second}
+
+	tests := []struct {
+		name         string
+		limit        time.Duration
+		wantComplete bool
+	}{
+		{"no limit", 0, false},
+		{"time left", 20 * time.Second, false},
+		{"time up", 10 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		mode := &challengeMode{GameMode: LookupGameMode(GameModeNormal), challenge: &Challenge{}, limit: tt.limit, passed: new(bool)}
+		if got := mode.IsComplete(state); got != tt.wantComplete {
+			t.Errorf("%s: IsComplete = %v, want %v", tt.name, got, tt.wantComplete)
+		}
+	}
+}
+
+func TestChallengeSeed(t *testing.T) {
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+
+	dir := t.TempDir()
+	load := func(yaml string) *Challenge {
+		t.Helper()
+		path := filepath.Join(dir, "seeded.yml")
+		if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		c, err := LoadChallenge(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	if c := load("mode: words\n"); c.Seed != nil {
+		t.Errorf("seed %d without one in the file", *c.Seed)
+	}
+
+	c := load("mode: words\nwords: 20\nseed: 0\n")
+	if c.Seed == nil || *c.Seed != 0 {
+		t.Fatalf("seed %v, want 0", c.Seed)
+	}
+
+	play := func() string {
+		CurrentSettings = DefaultSettings
+		c.apply()
+		return c.passage()
+	}
+	if first, again := play(), play(); first != again {
+		t.Errorf("seed 0 gave %q then %q", first, again)
+	}
+}
+
+func TestChallengeRestart(t *testing.T) {
+	testConfigDir(t)
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+	CurrentSettings = DefaultSettings
+
+	c := &Challenge{Name: "warmup", Mode: GameModeNormal, MinWPM: 30, TimeLimit: 20}
+	mode := newChallengeMode(c)
+	game := newChallengeGame(0, 0, "ab cd", mode)
+	game.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+
+	next, _ := game.Update(tea.KeyMsg{Type: tea.KeyTab})
+	restarted, ok := next.(*TypingModel)
+	switch {
+	case !ok:
+		t.Fatalf("TAB went to %T, want a new game", next)
+	case restarted.mode != mode:
+		t.Errorf("the restarted game plays %T, want the challenge", restarted.mode)
+	case restarted.banner != game.banner:
+		t.Errorf("banner %q, want %q", restarted.banner, game.banner)
+	case restarted.text.GetText() != "ab cd" || restarted.timerRunning:
+		t.Errorf("the restarted game didn't start over on the same passage")
+	}
+}
@@ -0,0 +1,431 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/race"
+)
+
+// classroomMode plays an instructor's assignment, with its time limit if it
+// has one. It is not registered, classrooms are joined with
+// `go-typer classroom join`.
+type classroomMode struct {
+	limit time.Duration // 0 for none
+}
+
+func (m *classroomMode) Name() string                              { return "classroom" }
+func (m *classroomMode) Title() string                             { return "Classroom" }
+func (m *classroomMode) Description() string                       { return "The passage handed out by an instructor" }
+func (m *classroomMode) Pipeline(settings UserSettings) []string   { return nil }
+func (m *classroomMode) GenerateText(settings UserSettings) string { return "" }
+
+func (m *classroomMode) IsComplete(state GameState) bool {
+	return (m.limit > 0 && state.Elapsed >= m.limit) || finishedText(state)
+}
+
+// Score only counts the words typed before the time ran out.
+func (m *classroomMode) Score(state GameState) GameResult {
+	if m.limit == 0 {
+		return scoreText(state)
+	}
+	total, correct, errors := state.Text.TypedStats()
+	return scoreWords(total, correct, errors, m.elapsed(state))
+}
+
+func (m *classroomMode) HUD(state GameState) string {
+	if m.limit == 0 {
+		return elapsedHUD(state)
+	}
+	remaining := m.limit
+	if state.Started {
+		remaining -= m.elapsed(state)
+	}
+	return TimerStyle.Render(formatDuration(remaining))
+}
+
+// elapsed is the game time, capped at the time limit.
+func (m *classroomMode) elapsed(state GameState) time.Duration {
+	switch {
+	case state.Elapsed < 0:
+		return 0
+	case m.limit > 0 && state.Elapsed > m.limit:
+		return m.limit
+	}
+	return state.Elapsed
+}
+
+// ClassroomModel is a student in an instructor's classroom: it waits for an
+// assignment, plays it and reports the result, then waits for the next round.
+type ClassroomModel struct {
+	client   *race.Client
+	addr     string
+	round    int // round of the assignment being typed
+	game     *TypingModel
+	end      *EndGameModel
+	err      error
+	width    int
+	height   int
+	lastTick time.Time
+}
+
+func NewClassroomModel(client *race.Client, addr string) *ClassroomModel {
+	return &ClassroomModel{
+		client:   client,
+		addr:     addr,
+		lastTick: time.Now(),
+	}
+}
+
+func (m *ClassroomModel) Init() tea.Cmd {
+	return tea.Batch(InitGlobalTick(), m.receive())
+}
+
+func (m *ClassroomModel) receive() tea.Cmd {
+	return func() tea.Msg {
+		msg, err := m.client.Receive()
+		if err != nil {
+			return raceErrMsg{err}
+		}
+		return raceMsg(msg)
+	}
+}
+
+// playing reports whether the student is typing an assignment.
+func (m *ClassroomModel) playing() bool {
+	return m.game != nil && m.end == nil
+}
+
+func (m *ClassroomModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.game != nil {
+			m.game.Update(msg)
+		}
+		if m.end != nil {
+			m.end.Update(msg)
+		}
+		return m, nil
+
+	case GlobalTickMsg:
+		switch {
+		case m.playing():
+			return m.updateGame(msg)
+		case m.end != nil:
+			_, cmd := m.end.Update(msg)
+			return m, cmd
+		}
+		var cmd tea.Cmd
+		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
+		return m, cmd
+
+	case raceMsg:
+		if msg.Type == race.MsgAssignment {
+			m.assign(race.Message(msg))
+		}
+		return m, m.receive()
+
+	case raceErrMsg:
+		devlog.Log("Classroom: %v", msg.err)
+		m.err = msg.err
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+
+		if m.err != nil {
+			return m, tea.Quit
+		}
+
+		switch {
+		case m.playing():
+			if msg.Type == tea.KeyTab {
+				return m, nil
+			}
+			return m.updateGame(msg)
+		case m.end != nil:
+			switch msg.String() {
+			case "q", "enter", " ":
+				return m, tea.Quit
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// assign starts a new game on the instructor's assignment.
+func (m *ClassroomModel) assign(msg race.Message) {
+	devlog.Log("Classroom: Round %d started", msg.Round)
+
+	m.round = msg.Round
+	m.end = nil
+	m.game = NewTypingModel(m.width, m.height, msg.Text)
+	m.game.mode = &classroomMode{limit: time.Duration(msg.TimeLimit) * time.Second}
+	m.game.bots = nil
+	if msg.ErrorPolicy != "" {
+		m.game.text.SetErrorPolicy(ParseErrorPolicy(msg.ErrorPolicy))
+	}
+	if msg.BackspacePolicy != "" {
+		m.game.text.SetBackspacePolicy(ParseBackspacePolicy(msg.BackspacePolicy))
+	}
+	m.game.banner = HelpStyle(fmt.Sprintf("Round %d • Error policy: %s • Backspace: %s",
+		msg.Round, m.game.text.ErrorPolicy().Title(), m.game.text.BackspacePolicy().Title()))
+}
+
+// updateGame forwards msg to the typing game, reports progress and sends the
+// result once the game hands over to its end screen.
+func (m *ClassroomModel) updateGame(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.game.Update(msg)
+
+	if end, ok := next.(*EndGameModel); ok {
+		m.end = end
+		m.end.classroom = true
+		elapsed := m.game.mode.(*classroomMode).elapsed(m.game.state())
+		if err := m.client.SendResult(m.round, end.wpm, end.accuracy, end.correct, end.errors, elapsed); err != nil {
+			m.err = err
+		}
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok {
+		caret := m.game.text.Caret()
+		wpm := m.game.mode.Score(m.game.state()).WPM
+		if err := m.client.SendProgress(m.round, caret.Word, caret.Letter, m.game.text.Progress(), wpm); err != nil {
+			m.err = err
+		}
+	}
+	return m, cmd
+}
+
+func (m *ClassroomModel) View() string {
+	if m.err != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			ErrorStyle.Render("Classroom closed: "+m.err.Error())+"\n\n"+HelpStyle("Press any key to quit"))
+	}
+
+	switch {
+	case m.end != nil:
+		return m.end.View()
+	case m.game != nil:
+		return m.game.View()
+	}
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		HintStyle(fmt.Sprintf("Joined %s as %s, waiting for the instructor to start...", m.addr, m.client.Name))+"\n\n"+
+			HelpStyle("Press ESC to leave"))
+}
+
+// RunClassroom joins the classroom at addr as a student.
+func RunClassroom(addr, name string) error {
+	client, err := race.Dial(addr, name)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	devlog.Log("Classroom: Joined %s as %s (%d)", addr, client.Name, client.ID)
+
+	model := NewClassroomModel(client, addr)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error running classroom: %w", err)
+	}
+	return model.err
+}
+
+// InstructorModel is the instructor's console: it starts rounds, shows how
+// every student is doing and exports the results.
+type InstructorModel struct {
+	classroom *race.Classroom
+	csvPath   string
+	status    string
+	width     int
+	height    int
+	lastTick  time.Time
+}
+
+func NewInstructorModel(classroom *race.Classroom, csvPath string) *InstructorModel {
+	return &InstructorModel{
+		classroom: classroom,
+		csvPath:   csvPath,
+		lastTick:  time.Now(),
+	}
+}
+
+func (m *InstructorModel) Init() tea.Cmd {
+	return InitGlobalTick()
+}
+
+func (m *InstructorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case GlobalTickMsg:
+		var cmd tea.Cmd
+		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, tea.Quit
+		case "s", "enter":
+			m.classroom.Start()
+			m.status = HintStyle(fmt.Sprintf("Round %d started", m.classroom.Round()))
+		case "e":
+			if err := m.export(); err != nil {
+				m.status = ErrorStyle.Render(err.Error())
+			} else {
+				m.status = HintStyle("Results exported to " + m.csvPath)
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+
+	return m, nil
+}
+
+// export writes every round's results to the CSV file.
+func (m *InstructorModel) export() error {
+	file, err := os.Create(m.csvPath)
+	if err != nil {
+		return fmt.Errorf("could not export results: %w", err)
+	}
+	defer file.Close()
+
+	if err := m.classroom.WriteCSV(file); err != nil {
+		return fmt.Errorf("could not export results: %w", err)
+	}
+	return nil
+}
+
+func (m *InstructorModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(GetColor("timer")).
+		Render("Classroom")
+
+	assignment := m.classroom.Assignment
+	rules := []string{"no time limit"}
+	if assignment.TimeLimit > 0 {
+		rules[0] = fmt.Sprintf("%ds time limit", assignment.TimeLimit)
+	}
+	if assignment.ErrorPolicy != "" {
+		rules = append(rules, "error policy: "+ErrorPolicy(assignment.ErrorPolicy).Title())
+	}
+	if assignment.BackspacePolicy != "" {
+		rules = append(rules, "backspace: "+BackspacePolicy(assignment.BackspacePolicy).Title())
+	}
+
+	passage := []rune(assignment.Text)
+	if len(passage) > MaxWidth {
+		passage = append(passage[:MaxWidth-1], '…')
+	}
+
+	round := "Waiting to start"
+	if r := m.classroom.Round(); r > 0 {
+		round = fmt.Sprintf("Round %d", r)
+	}
+
+	content := title + "  " + HelpStyle(fmt.Sprintf("students join with: go-typer classroom join %s", m.classroom.Addr())) + "\n\n" +
+		lipgloss.NewStyle().Foreground(GetColor("text_preview")).Render(string(passage)) + "\n" +
+		HelpStyle(strings.Join(rules, " • ")) + "\n\n" +
+		TimerStyle.Render(round) + "\n\n" +
+		m.renderStudents()
+
+	if m.status != "" {
+		content += "\n\n" + m.status
+	}
+	content += "\n\n" + HelpStyle("s start a round • e export CSV • q quit")
+
+	// NOTE: pad every line to the same width so the table stays aligned
+	content = lipgloss.NewStyle().Align(lipgloss.Left).Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// renderStudents is the live table of the current round.
+func (m *InstructorModel) renderStudents() string {
+	students := m.classroom.Students()
+	if len(students) == 0 {
+		return HintStyle("No students yet")
+	}
+
+	results := make(map[int]race.ClassResult)
+	round := m.classroom.Round()
+	for _, result := range m.classroom.Results() {
+		if result.Round == round {
+			results[result.ID] = result
+		}
+	}
+
+	nameWidth := len("Student")
+	for _, student := range students {
+		nameWidth = max(nameWidth, lipgloss.Width(student.Name))
+	}
+
+	header := fmt.Sprintf("%-*s  %-*s  %7s  %8s  %7s  %5s  %6s", nameWidth, "Student", standingsBarWidth, "Progress", "WPM", "Accuracy", "Correct", "Wrong", "Time")
+	lines := []string{HelpStyle(header)}
+
+	for _, student := range students {
+		filled := int(student.Progress * standingsBarWidth)
+		bar := lipgloss.NewStyle().Foreground(GetColor("timer")).Render(strings.Repeat("█", filled)) +
+			DimStyle.Render(strings.Repeat("░", standingsBarWidth-filled))
+
+		name := student.Name + strings.Repeat(" ", nameWidth-lipgloss.Width(student.Name))
+		style := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+
+		var stats string
+		switch result, finished := results[student.ID]; {
+		case finished:
+			style = style.Foreground(GetColor("text_correct"))
+			stats = fmt.Sprintf("%7.1f  %7.1f%%  %7d  %5d  %6s", result.WPM, result.Accuracy, result.Correct, result.Mistakes, formatDuration(result.Elapsed))
+		case student.Left:
+			style = DimStyle
+			stats = "left"
+		case round == 0:
+			stats = "waiting"
+		default:
+			stats = fmt.Sprintf("%7.1f  %8s", student.WPM, "typing")
+		}
+
+		lines = append(lines, style.Render(name)+"  "+bar+"  "+style.Render(stats))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RunInstructor hosts classroom and shows the instructor's console until
+// they quit. The results are exported to csvPath on the way out.
+func RunInstructor(classroom *race.Classroom, csvPath string) error {
+	go func() {
+		if err := classroom.Serve(); err != nil {
+			devlog.Log("Classroom: %v", err)
+		}
+	}()
+	defer classroom.Close()
+
+	model := NewInstructorModel(classroom, csvPath)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error running classroom: %w", err)
+	}
+
+	if len(classroom.Results()) == 0 {
+		return nil
+	}
+	if err := model.export(); err != nil {
+		return err
+	}
+	fmt.Printf("Results exported to %s\n", csvPath)
+	return nil
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/prime-run/go-typer/race"
+)
+
+func TestClassroomNewRound(t *testing.T) {
+	testConfigDir(t)
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+	CurrentSettings = DefaultSettings
+
+	classroom := race.NewClassroom(race.Assignment{Text: "ab cd"})
+	if err := classroom.Listen("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	go classroom.Serve()
+	t.Cleanup(classroom.Close)
+
+	client, err := race.Dial(classroom.Addr().String(), "ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	model := NewClassroomModel(client, classroom.Addr().String())
+	press := func(keys string) {
+		for _, r := range keys {
+			key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+			if r == ' ' {
+				key = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}}
+			}
+			model.Update(key)
+		}
+	}
+	// receive waits for the next message in the background, like the program does
+	receive := func() <-chan tea.Msg {
+		received := make(chan tea.Msg, 1)
+		go func() { received <- model.receive()() }()
+		return received
+	}
+	wait := func(received <-chan tea.Msg) tea.Msg {
+		select {
+		case msg := <-received:
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the instructor")
+			return nil
+		}
+	}
+
+	classroom.Start()
+	model.Update(wait(receive()))
+	press("ab c")
+
+	// the second round is handed out while the first is being finished
+	next := receive()
+	classroom.Start()
+	press("d")
+	model.Update(wait(next))
+	if model.end != nil || model.round != 2 {
+		t.Fatalf("round %d (ended %v), want a new game of round 2", model.round, model.end != nil)
+	}
+	press("ab cd")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(classroom.Results()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	results := classroom.Results()
+	if len(results) != 1 || results[0].Round != 2 {
+		t.Errorf("results %+v, want one for round 2", results)
+	}
+}
@@ -19,13 +19,14 @@ func NewCursor(style CursorType) *Cursor {
 	}
 }
 
-func (c *Cursor) Render(char rune) string {
+// Render draws the cursor over a single grapheme cluster.
+func (c *Cursor) Render(char string) string {
 	switch c.style {
 	case BlockCursor:
-		return BlockCursorStyle.Render(string(char))
+		return BlockCursorStyle.Render(char)
 	case UnderlineCursor:
-		return UnderlineCursorStyle.Render(string(char))
+		return UnderlineCursorStyle.Render(char)
 	default:
-		return BlockCursorStyle.Render(string(char))
+		return BlockCursorStyle.Render(char)
 	}
 }
@@ -0,0 +1,445 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	GameModeDaily = "daily" // The passage of the day, not offered in the settings
+
+	dailyWords      = 30           // Words in the daily passage
+	dailyDateFormat = "2006-01-02" // Dates the daily results are stored under
+
+	shareCodePrefix   = "GT-"
+	shareCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ" // Crockford's base32, no I, L, O or U
+	shareCodeChars    = 8                                  // 40 bits: day, WPM, accuracy and a checksum
+)
+
+// dailyEpoch is day 0 of the share codes, which count days in 14 bits.
+var dailyEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// dailyMode is the passage of the day, the same for everyone on that date.
+// Only the first game started that day is scored, quitting it still uses up
+// the attempt, and later games are practice.
+type dailyMode struct {
+	date     string
+	practice bool // today's scored attempt was already taken
+	attempt  bool // this game is today's scored attempt
+}
+
+func (m *dailyMode) Name() string                            { return GameModeDaily }
+func (m *dailyMode) Title() string                           { return "Daily" }
+func (m *dailyMode) Description() string                     { return "The passage of the day, one scored attempt" }
+func (m *dailyMode) Pipeline(settings UserSettings) []string { return nil }
+
+func (m *dailyMode) GenerateText(settings UserSettings) string {
+	return DailyText(m.date)
+}
+
+func (m *dailyMode) IsComplete(state GameState) bool  { return finishedText(state) }
+func (m *dailyMode) Score(state GameState) GameResult { return scoreText(state) }
+
+func (m *dailyMode) HUD(state GameState) string {
+	label := "Daily " + m.date
+	if m.practice {
+		label += " • practice"
+	}
+	return HelpStyle(label) + "  " + elapsedHUD(state)
+}
+
+// Start takes up today's scored attempt, before a key is typed so quitting
+// halfway can't be used to try again.
+func (m *dailyMode) Start() {
+	if m.practice {
+		return
+	}
+	if m.attempt {
+		// NOTE: restarted with TAB, the attempt is gone
+		m.practice, m.attempt = true, false
+		return
+	}
+
+	progress, err := LoadDailyProgress()
+	if err != nil {
+		devlog.Log("Daily: %v", err)
+	}
+	if !progress.Start(m.date) {
+		m.practice = true
+		return
+	}
+	if err := progress.Save(); err != nil {
+		devlog.Log("Daily: Could not save progress: %v", err)
+	}
+	m.attempt = true
+}
+
+func (m *dailyMode) Finish(result GameResult, end *EndGameModel) {
+	end.daily = recordDailyResult(m.date, !m.attempt, result.WPM, result.Accuracy)
+	m.attempt = false
+}
+
+// DailyDate returns the date of the daily passage played at t. Days are UTC
+// days, so everyone is on the same passage at the same time.
+func DailyDate(t time.Time) string {
+	return t.UTC().Format(dailyDateFormat)
+}
+
+// dailyDay counts the days from dailyEpoch to date.
+func dailyDay(date string) (int, error) {
+	t, err := time.Parse(dailyDateFormat, date)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	return int(t.Sub(dailyEpoch).Hours() / 24), nil
+}
+
+// DailyText derives the passage for a date from the embedded English pack,
+// so every copy of go-typer types the same words without going online.
+func DailyText(date string) string {
+	day, err := dailyDay(date)
+	if err != nil {
+		devlog.Log("Daily: %v", err)
+	}
+
+	pack, err := embeddedLanguagePack(DefaultLanguage)
+	if err != nil || len(pack.Words) == 0 {
+		devlog.Log("Daily: Could not load the %s pack: %v", DefaultLanguage, err)
+		return "the quick brown fox jumps over the lazy dog"
+	}
+
+	// NOTE: seeded sources are stable across Go releases, the passage of a
+	// date never changes
+	rng := rand.New(rand.NewSource(int64(day)))
+	words := make([]string, dailyWords)
+	for i := range words {
+		words[i] = pack.Words[rng.Intn(len(pack.Words))]
+	}
+	return strings.Join(words, " ")
+}
+
+// embeddedLanguagePack loads a pack as shipped, ignoring user overrides.
+func embeddedLanguagePack(code string) (*LanguagePack, error) {
+	data, err := embeddedLanguages.ReadFile(languagesDirName + "/" + code + YMLSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("language pack not found: %s", code)
+	}
+
+	var pack LanguagePack
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("error parsing language pack %s: %w", code, err)
+	}
+	return &pack, nil
+}
+
+// DailyResult is the scored attempt of a day.
+type DailyResult struct {
+	WPM       float64 `json:"wpm"`
+	Accuracy  float64 `json:"accuracy"`
+	Abandoned bool    `json:"abandoned,omitempty"` // started but never finished
+}
+
+// DailyProgress keeps one result per day and the streak of days in a row.
+type DailyProgress struct {
+	Results    map[string]DailyResult `json:"results"`
+	Last       string                 `json:"last"` // last day played
+	Streak     int                    `json:"streak"`
+	BestStreak int                    `json:"best_streak"`
+}
+
+func GetDailyProgressFilePath() (string, error) {
+	configDir, err := utils.GetAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "daily.json"), nil
+}
+
+func LoadDailyProgress() (*DailyProgress, error) {
+	progress := &DailyProgress{Results: make(map[string]DailyResult)}
+
+	progressPath, err := GetDailyProgressFilePath()
+	if err != nil {
+		return progress, fmt.Errorf("failed to get daily progress file path: %w", err)
+	}
+
+	data, err := os.ReadFile(progressPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return progress, nil
+		}
+		return progress, fmt.Errorf("error reading daily progress file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, progress); err != nil {
+		return progress, fmt.Errorf("error parsing daily progress file: %w", err)
+	}
+
+	if progress.Results == nil {
+		progress.Results = make(map[string]DailyResult)
+	}
+
+	return progress, nil
+}
+
+func (p *DailyProgress) Save() error {
+	progressPath, err := GetDailyProgressFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get daily progress file path: %w", err)
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling daily progress: %w", err)
+	}
+
+	if err := os.WriteFile(progressPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing daily progress file: %w", err)
+	}
+
+	return nil
+}
+
+// Played reports whether the scored attempt of date was taken.
+func (p *DailyProgress) Played(date string) bool {
+	_, ok := p.Results[date]
+	return ok
+}
+
+// Start takes the scored attempt of date, which stays abandoned until Record
+// fills in its result, and extends the streak. It returns false if that day
+// was already played.
+func (p *DailyProgress) Start(date string) bool {
+	if p.Played(date) {
+		return false
+	}
+	p.Results[date] = DailyResult{Abandoned: true}
+
+	day, _ := dailyDay(date)
+	last, err := dailyDay(p.Last)
+	switch {
+	case err == nil && last == day-1:
+		p.Streak++
+	case err == nil && last >= day:
+		// NOTE: a missed day filled in later doesn't touch the streak
+		return true
+	default:
+		p.Streak = 1
+	}
+	p.Last = date
+	p.BestStreak = max(p.BestStreak, p.Streak)
+	return true
+}
+
+// Record stores the result of the scored attempt of date, taken with Start.
+// It returns false if there is no attempt waiting for its result.
+func (p *DailyProgress) Record(date string, wpm, accuracy float64) bool {
+	if result, ok := p.Results[date]; !ok || !result.Abandoned {
+		return false
+	}
+	p.Results[date] = DailyResult{WPM: wpm, Accuracy: accuracy}
+	return true
+}
+
+// CurrentStreak is the streak as of date, broken once a day is skipped.
+func (p *DailyProgress) CurrentStreak(date string) int {
+	day, err := dailyDay(date)
+	if err != nil {
+		return 0
+	}
+	last, err := dailyDay(p.Last)
+	if err != nil || day-last > 1 {
+		return 0
+	}
+	return p.Streak
+}
+
+// dailySummary is what the end screen shows of a daily game.
+type dailySummary struct {
+	date       string
+	practice   bool        // the game wasn't scored
+	result     DailyResult // the day's scored attempt
+	streak     int
+	bestStreak int
+}
+
+// recordDailyResult stores the result of the day's scored attempt and returns
+// what to show on the end screen.
+func recordDailyResult(date string, practice bool, wpm, accuracy float64) *dailySummary {
+	progress, err := LoadDailyProgress()
+	if err != nil {
+		devlog.Log("Daily: %v", err)
+	}
+
+	scored := !practice && progress.Record(date, wpm, accuracy)
+	if scored {
+		if err := progress.Save(); err != nil {
+			devlog.Log("Daily: Could not save progress: %v", err)
+		}
+	}
+
+	return &dailySummary{
+		date:       date,
+		practice:   !scored,
+		result:     progress.Results[date],
+		streak:     progress.CurrentStreak(date),
+		bestStreak: progress.BestStreak,
+	}
+}
+
+// ShareCode packs a daily result into a short code like GT-0K8M-4Z7Q: the
+// day, WPM and accuracy to a tenth, and a checksum against typos.
+func ShareCode(date string, wpm, accuracy float64) (string, error) {
+	day, err := dailyDay(date)
+	if err != nil {
+		return "", err
+	}
+	if day < 0 || day >= 1<<14 {
+		return "", fmt.Errorf("date %s is out of range", date)
+	}
+
+	wpm10 := uint64(math.Round(math.Min(math.Max(wpm, 0), 409.5) * 10))
+	accuracy10 := uint64(math.Round(math.Min(math.Max(accuracy, 0), 100) * 10))
+	value := uint64(day)<<22 | wpm10<<10 | accuracy10
+	value = value<<4 | shareCodeChecksum(value)
+
+	code := make([]byte, shareCodeChars)
+	for i := shareCodeChars - 1; i >= 0; i-- {
+		code[i] = shareCodeAlphabet[value&31]
+		value >>= 5
+	}
+	return shareCodePrefix + string(code[:4]) + "-" + string(code[4:]), nil
+}
+
+// DecodeShareCode reads a code made by ShareCode back into its date, WPM
+// and accuracy. Case, dashes and the prefix are optional.
+func DecodeShareCode(code string) (date string, wpm, accuracy float64, err error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	code = strings.TrimPrefix(code, shareCodePrefix)
+	code = strings.ReplaceAll(code, "-", "")
+	if len(code) != shareCodeChars {
+		return "", 0, 0, errors.New("a share code has 8 letters or digits")
+	}
+
+	var value uint64
+	for _, r := range code {
+		// NOTE: letters that read like digits are taken as those digits
+		switch r {
+		case 'O':
+			r = '0'
+		case 'I', 'L':
+			r = '1'
+		}
+		digit := strings.IndexRune(shareCodeAlphabet, r)
+		if digit < 0 {
+			return "", 0, 0, fmt.Errorf("%q is not part of a share code", r)
+		}
+		value = value<<5 | uint64(digit)
+	}
+
+	if shareCodeChecksum(value>>4) != value&15 {
+		return "", 0, 0, errors.New("the share code has a typo")
+	}
+	value >>= 4
+
+	accuracy10 := value & (1<<10 - 1)
+	if accuracy10 > 1000 {
+		return "", 0, 0, errors.New("the share code has a typo")
+	}
+	wpm10 := value >> 10 & (1<<12 - 1)
+	day := int(value >> 22)
+
+	date = DailyDate(dailyEpoch.AddDate(0, 0, day))
+	return date, float64(wpm10) / 10, float64(accuracy10) / 10, nil
+}
+
+func shareCodeChecksum(value uint64) uint64 {
+	var data [8]byte
+	for i := range data {
+		data[i] = byte(value >> (8 * i))
+	}
+	return uint64(crc32.ChecksumIEEE(data[:]) & 15)
+}
+
+func (m *EndGameModel) renderDailyResult() string {
+	summary := m.daily
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+
+	var lines []string
+	switch {
+	case summary.practice && summary.result.Abandoned:
+		lines = append(lines, titleStyle.Render("Daily "+summary.date+" • practice")+" "+
+			HelpStyle("(scored attempt abandoned)"))
+	case summary.practice:
+		lines = append(lines, titleStyle.Render("Daily "+summary.date+" • practice")+" "+
+			HelpStyle(fmt.Sprintf("(scored attempt: %.1f WPM, %.1f%%)", summary.result.WPM, summary.result.Accuracy)))
+	default:
+		lines = append(lines, titleStyle.Render("Daily "+summary.date+" done!"))
+	}
+
+	streak := fmt.Sprintf("Streak: %d day", summary.streak)
+	if summary.streak != 1 {
+		streak += "s"
+	}
+	lines = append(lines, HelpStyle(fmt.Sprintf("%s • best %d", streak, summary.bestStreak)))
+
+	if summary.result.Abandoned {
+		return strings.Join(lines, "\n")
+	}
+	if code, err := ShareCode(summary.date, summary.result.WPM, summary.result.Accuracy); err == nil {
+		lines = append(lines, HintStyle("Share: ")+
+			lipgloss.NewStyle().Foreground(GetColor("text_correct")).Bold(true).Render(code))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *EndGameModel) selectDailyOption() (tea.Model, tea.Cmd) {
+	if m.selectedItem != 0 {
+		return m, tea.Quit
+	}
+
+	model := NewTypingModel(m.width, m.height, m.text)
+	model.mode = &dailyMode{date: m.daily.date, practice: true}
+	return model, InitGlobalTick()
+}
+
+// NewDailyModel starts a game on today's passage.
+func NewDailyModel(width, height int) *TypingModel {
+	mode := &dailyMode{date: DailyDate(time.Now())}
+
+	progress, err := LoadDailyProgress()
+	if err != nil {
+		devlog.Log("Daily: %v", err)
+	}
+	mode.practice = progress.Played(mode.date)
+
+	model := NewTypingModel(width, height, mode.GenerateText(CurrentSettings))
+	model.mode = mode
+	return model
+}
+
+// RunDaily plays today's passage until the user quits.
+func RunDaily() {
+	devlog.Log("Daily: Starting the passage of %s", DailyDate(time.Now()))
+
+	p := tea.NewProgram(NewDailyModel(0, 0), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error running daily: %v\n", err)
+	}
+}
@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// testConfigDir points the config directory at an empty temporary one.
+func testConfigDir(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+}
+
+func TestShareCode(t *testing.T) {
+	tests := []struct {
+		date         string
+		wpm          float64
+		accuracy     float64
+		want         string
+		wantWPM      float64
+		wantAccuracy float64
+	}{
+		{"2026-10-19", 72.5, 98.3, "GT-1ZWB-AZBJ", 72.5, 98.3},
+		{"2024-01-01", 0, 0, "GT-0000-0009", 0, 0},
+		{"2025-06-01", 101.1, 91.1, "GT-10AF-SY7P", 101.1, 91.1},
+		{"2068-11-08", 1, 1, "GT-ZZY0-5054", 1, 1},
+		{"2026-10-19", 72.46, 98.34, "GT-1ZWB-AZBJ", 72.5, 98.3},
+		{"2026-10-19", 500, 120, "", 409.5, 100},
+		{"2026-10-19", -3, -1, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		code, err := ShareCode(tt.date, tt.wpm, tt.accuracy)
+		if err != nil {
+			t.Errorf("ShareCode(%s, %g, %g): %v", tt.date, tt.wpm, tt.accuracy, err)
+			continue
+		}
+		if tt.want != "" && code != tt.want {
+			t.Errorf("ShareCode(%s, %g, %g) = %s, want %s", tt.date, tt.wpm, tt.accuracy, code, tt.want)
+		}
+
+		date, wpm, accuracy, err := DecodeShareCode(code)
+		if err != nil {
+			t.Errorf("DecodeShareCode(%s): %v", code, err)
+			continue
+		}
+		if date != tt.date || wpm != tt.wantWPM || accuracy != tt.wantAccuracy {
+			t.Errorf("DecodeShareCode(%s) = %s, %g, %g, want %s, %g, %g", code, date, wpm, accuracy, tt.date, tt.wantWPM, tt.wantAccuracy)
+		}
+	}
+}
+
+func TestShareCodeDateRange(t *testing.T) {
+	for _, date := range []string{"2023-12-31", "2068-11-09", "19-10-2026", ""} {
+		if code, err := ShareCode(date, 50, 90); err == nil {
+			t.Errorf("ShareCode(%q) = %s, want an error", date, code)
+		}
+	}
+}
+
+func TestDecodeShareCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		wantDate string
+		wantErr  string // part of the error, empty for none
+	}{
+		{"as shown", "GT-1ZWB-AZBJ", "2026-10-19", ""},
+		{"lowercase", "gt-1zwb-azbj", "2026-10-19", ""},
+		{"without prefix and dashes", "1ZWBAZBJ", "2026-10-19", ""},
+		{"surrounding spaces", "  GT-1ZWB-AZBJ\n", "2026-10-19", ""},
+		{"O for zero", "GT-1OAF-SY7P", "2025-06-01", ""},
+		{"I and L for one", "GT-I0AF-SY7P", "2025-06-01", ""},
+		{"all lookalikes", "gt-oooo-ooo9", "2024-01-01", ""},
+		{"too short", "GT-1ZWB-AZB", "", "8 letters or digits"},
+		{"too long", "GT-1ZWB-AZBJJ", "", "8 letters or digits"},
+		{"not in the alphabet", "GT-1ZWB-AZBU", "", "not part of a share code"},
+		{"typo in the day", "GT-2ZWB-AZBJ", "", "typo"},
+		{"typo in the score", "GT-1ZWX-AZBJ", "", "typo"},
+		{"typo in the checksum", "GT-1ZWB-AZBK", "", "typo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _, _, err := DecodeShareCode(tt.code)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("error %v, want one about %q", err, tt.wantErr)
+			case date != tt.wantDate:
+				t.Errorf("date %q, want %q", date, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestDailyDate(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), "2026-10-19"},
+		{time.Date(2026, 10, 20, 5, 0, 0, 0, time.FixedZone("UTC+14", 14*3600)), "2026-10-19"},
+		{time.Date(2026, 10, 19, 20, 0, 0, 0, time.FixedZone("UTC-10", -10*3600)), "2026-10-20"},
+	}
+
+	for _, tt := range tests {
+		if got := DailyDate(tt.t); got != tt.want {
+			t.Errorf("DailyDate(%s) = %s, want %s", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestDailyText(t *testing.T) {
+	text := DailyText("2026-10-19")
+	if words := strings.Fields(text); len(words) != dailyWords {
+		t.Errorf("%d words, want %d", len(words), dailyWords)
+	}
+	if again := DailyText("2026-10-19"); again != text {
+		t.Errorf("the passage of a day changed: %q then %q", text, again)
+	}
+	if next := DailyText("2026-10-20"); next == text {
+		t.Errorf("two days share the passage %q", text)
+	}
+}
+
+func TestDailyProgress(t *testing.T) {
+	type step struct {
+		op         string // start or record
+		date       string
+		want       bool
+		wantStreak int // as of the date
+		wantBest   int
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"days in a row", []step{
+			{"start", "2026-10-01", true, 1, 1},
+			{"record", "2026-10-01", true, 1, 1},
+			{"start", "2026-10-02", true, 2, 2},
+			{"record", "2026-10-02", true, 2, 2},
+		}},
+		{"one attempt a day", []step{
+			{"start", "2026-10-01", true, 1, 1},
+			{"record", "2026-10-01", true, 1, 1},
+			{"start", "2026-10-01", false, 1, 1},
+			{"record", "2026-10-01", false, 1, 1},
+		}},
+		{"record without a start", []step{
+			{"record", "2026-10-01", false, 0, 0},
+		}},
+		{"an abandoned attempt keeps the streak", []step{
+			{"start", "2026-10-01", true, 1, 1},
+			{"record", "2026-10-01", true, 1, 1},
+			{"start", "2026-10-02", true, 2, 2},
+			{"start", "2026-10-02", false, 2, 2},
+		}},
+		{"a skipped day breaks the streak", []step{
+			{"start", "2026-10-01", true, 1, 1},
+			{"start", "2026-10-02", true, 2, 2},
+			{"start", "2026-10-04", true, 1, 2},
+		}},
+		{"a missed day filled in later", []step{
+			{"start", "2026-10-01", true, 1, 1},
+			{"start", "2026-10-03", true, 1, 1},
+			{"start", "2026-10-02", true, 1, 1},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := &DailyProgress{Results: make(map[string]DailyResult)}
+			for i, s := range tt.steps {
+				var got bool
+				if s.op == "start" {
+					got = progress.Start(s.date)
+				} else {
+					got = progress.Record(s.date, 60, 95)
+				}
+				if got != s.want {
+					t.Fatalf("step %d: %s %s = %v, want %v", i+1, s.op, s.date, got, s.want)
+				}
+				if streak := progress.CurrentStreak(s.date); streak != s.wantStreak || progress.BestStreak != s.wantBest {
+					t.Fatalf("step %d: streak %d (best %d), want %d (best %d)", i+1, streak, progress.BestStreak, s.wantStreak, s.wantBest)
+				}
+			}
+		})
+	}
+}
+
+func TestDailyAttempt(t *testing.T) {
+	const date = "2026-10-19"
+	result := GameResult{WPM: 70, Accuracy: 97}
+
+	tests := []struct {
+		name         string
+		play         func() *dailySummary // plays the games, returns what the last one showed
+		wantPractice bool
+		wantResult   DailyResult
+	}{
+		{"finished attempt", func() *dailySummary {
+			mode := &dailyMode{date: date}
+			mode.Start()
+			return finishDaily(mode, result)
+		}, false, DailyResult{WPM: 70, Accuracy: 97}},
+		{"practice after the attempt", func() *dailySummary {
+			first := &dailyMode{date: date}
+			first.Start()
+			finishDaily(first, result)
+
+			again := &dailyMode{date: date}
+			again.Start()
+			return finishDaily(again, GameResult{WPM: 90, Accuracy: 100})
+		}, true, DailyResult{WPM: 70, Accuracy: 97}},
+		{"quitting uses up the attempt", func() *dailySummary {
+			quit := &dailyMode{date: date}
+			quit.Start()
+
+			again := &dailyMode{date: date}
+			again.Start()
+			return finishDaily(again, result)
+		}, true, DailyResult{Abandoned: true}},
+		{"restarting uses up the attempt", func() *dailySummary {
+			mode := &dailyMode{date: date}
+			mode.Start()
+			mode.Start()
+			return finishDaily(mode, result)
+		}, true, DailyResult{Abandoned: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testConfigDir(t)
+
+			summary := tt.play()
+			if summary.practice != tt.wantPractice {
+				t.Errorf("practice = %v, want %v", summary.practice, tt.wantPractice)
+			}
+			if summary.result != tt.wantResult {
+				t.Errorf("scored result %+v, want %+v", summary.result, tt.wantResult)
+			}
+
+			progress, err := LoadDailyProgress()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved := progress.Results[date]; saved != tt.wantResult {
+				t.Errorf("saved result %+v, want %+v", saved, tt.wantResult)
+			}
+		})
+	}
+}
+
+// finishDaily ends a daily game the way the end screen does.
+func finishDaily(mode *dailyMode, result GameResult) *dailySummary {
+	end := NewEndGameModel(result.WPM, result.Accuracy, 0, 0, 0, "")
+	mode.Finish(result, end)
+	return end.daily
+}
+
+func TestReplayDaily(t *testing.T) {
+	if mode := replayMode(GameModeDaily); mode.Name() != GameModeDaily {
+		t.Errorf("daily games are replayed in %s mode", mode.Name())
+	}
+}
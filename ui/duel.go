@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+)
+
+// duelPaneWidth is the width of a player's pane, the passage plus a margin.
+const duelPaneWidth = MaxWidth + 4
+
+type duelPlayer struct {
+	name   string
+	game   *TypingModel
+	result *GameResult // nil until the player's turn is over
+}
+
+// DuelModel is a hot-seat duel on one machine: two players type the same
+// passage one after the other and their results are compared at the end.
+type DuelModel struct {
+	players      [2]*duelPlayer
+	text         string
+	turn         int  // index of the player typing or about to type
+	ready        bool // the player whose turn it is pressed enter
+	done         bool
+	selectedItem int
+	width        int
+	height       int
+	lastTick     time.Time
+}
+
+func NewDuelModel(width, height int, text string, names [2]string) *DuelModel {
+	m := &DuelModel{
+		text:     text,
+		width:    width,
+		height:   height,
+		lastTick: time.Now(),
+	}
+	for i, name := range names {
+		if name == "" {
+			name = fmt.Sprintf("Player %d", i+1)
+		}
+		game := NewTypingModel(width, height, text)
+		game.mode = duelMode()
+		game.bots = nil
+		m.players[i] = &duelPlayer{name: name, game: game}
+	}
+	return m
+}
+
+// duelMode is the active game mode, unless it acts on finished games (like
+// recording lesson progress), which a duel shouldn't trigger.
+func duelMode() GameMode {
+	mode := ActiveGameMode()
+	if _, ok := mode.(gameFinisher); ok {
+		return LookupGameMode(GameModeNormal)
+	}
+	return mode
+}
+
+func (m *DuelModel) Init() tea.Cmd {
+	return InitGlobalTick()
+}
+
+func (m *DuelModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		for _, player := range m.players {
+			player.game.Update(msg)
+		}
+		return m, nil
+
+	case GlobalTickMsg:
+		if m.playing() {
+			return m.updateGame(msg)
+		}
+		var cmd tea.Cmd
+		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+
+		switch {
+		case m.done:
+			return m.updateResults(msg)
+		case !m.ready:
+			if msg.Type == tea.KeyEnter {
+				m.ready = true
+			}
+			return m, nil
+		case msg.Type == tea.KeyTab:
+			return m, nil
+		}
+		return m.updateGame(msg)
+	}
+
+	return m, nil
+}
+
+// playing reports whether a player is typing.
+func (m *DuelModel) playing() bool {
+	return m.ready && !m.done
+}
+
+// updateGame forwards msg to the player whose turn it is and hands over to
+// the next player once their game is over.
+func (m *DuelModel) updateGame(msg tea.Msg) (tea.Model, tea.Cmd) {
+	player := m.players[m.turn]
+	next, cmd := player.game.Update(msg)
+
+	if end, ok := next.(*EndGameModel); ok {
+		player.game.gameComplete = true
+		player.result = &GameResult{
+			WPM:      end.wpm,
+			Accuracy: end.accuracy,
+			Words:    end.words,
+			Correct:  end.correct,
+			Errors:   end.errors,
+			Extra:    end.extra,
+		}
+		devlog.Log("Duel: %s finished with %.1f WPM", player.name, end.wpm)
+
+		m.ready = false
+		if m.turn+1 < len(m.players) {
+			m.turn++
+		} else {
+			m.done = true
+		}
+		return m, InitGlobalTick()
+	}
+
+	return m, cmd
+}
+
+func (m *DuelModel) updateResults(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := duelOptions()
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.selectedItem = (m.selectedItem + len(options) - 1) % len(options)
+	case "down", "j":
+		m.selectedItem = (m.selectedItem + 1) % len(options)
+	case "enter", " ":
+		names := [2]string{m.players[0].name, m.players[1].name}
+		switch m.selectedItem {
+		case 0:
+			return NewDuelModel(m.width, m.height, m.text, names), InitGlobalTick()
+		case 1:
+			return NewDuelModel(m.width, m.height, duelMode().GenerateText(CurrentSettings), names), InitGlobalTick()
+		default:
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func duelOptions() []string {
+	return []string{"Rematch with Same Text", "Rematch with New Text", "Quit"}
+}
+
+func (m *DuelModel) View() string {
+	if m.done {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.renderResults())
+	}
+
+	panes := []string{m.renderPane(0), m.renderPane(1)}
+
+	var board string
+	if m.width >= duelPaneWidth*2 {
+		board = lipgloss.JoinHorizontal(lipgloss.Top, panes...)
+	} else {
+		board = lipgloss.JoinVertical(lipgloss.Left, panes...)
+	}
+
+	var prompt string
+	if m.ready {
+		prompt = HelpStyle(fmt.Sprintf("%s is typing • ESC to quit", m.players[m.turn].name))
+	} else {
+		prompt = TimerStyle.Render(fmt.Sprintf("%s, press Enter when ready", m.players[m.turn].name))
+	}
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		board+"\n\n"+prompt)
+}
+
+// renderPane draws a player's name, HUD and passage.
+func (m *DuelModel) renderPane(i int) string {
+	player := m.players[i]
+
+	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(GetColor("text_preview"))
+	if i == m.turn {
+		nameStyle = nameStyle.Foreground(GetColor("timer"))
+	}
+
+	var status string
+	switch {
+	case player.result != nil:
+		status = HelpStyle(fmt.Sprintf("%.1f WPM • %.1f%%", player.result.WPM, player.result.Accuracy))
+	case i == m.turn && m.ready:
+		status = renderModeHUD(player.game.mode, player.game.state())
+	default:
+		status = HelpStyle("waiting for their turn")
+	}
+
+	return lipgloss.NewStyle().
+		Width(duelPaneWidth).
+		Render(nameStyle.Render(player.name) + "  " + status + "\n" + player.game.text.Render())
+}
+
+func (m *DuelModel) renderResults() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(GetColor("text_correct")).
+		Render("Duel Complete!")
+
+	a, b := m.players[0].result, m.players[1].result
+
+	winner := "It's a tie!"
+	switch compareResults(*a, *b) {
+	case 1:
+		winner = m.players[0].name + " wins!"
+	case -1:
+		winner = m.players[1].name + " wins!"
+	}
+	winnerText := RenderGradientOverlay(winner, lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true), m.lastTick)
+
+	nameWidth := max(lipgloss.Width(m.players[0].name), lipgloss.Width(m.players[1].name))
+	nameWidth = max(nameWidth, 10)
+
+	better := lipgloss.NewStyle().Foreground(GetColor("text_correct")).Bold(true)
+	plain := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+	cell := func(value string, best bool) string {
+		value = fmt.Sprintf("%-*s", nameWidth, value)
+		if best {
+			return better.Render(value)
+		}
+		return plain.Render(value)
+	}
+
+	rows := []string{
+		HelpStyle(fmt.Sprintf("%-10s", "")) + "  " + cell(m.players[0].name, false) + "  " + cell(m.players[1].name, false),
+	}
+	addRow := func(label, left, right string, cmp int) {
+		rows = append(rows, HelpStyle(fmt.Sprintf("%-10s", label))+"  "+cell(left, cmp > 0)+"  "+cell(right, cmp < 0))
+	}
+	addRow("WPM", fmt.Sprintf("%.1f", a.WPM), fmt.Sprintf("%.1f", b.WPM), compareFloat(a.WPM, b.WPM))
+	addRow("Accuracy", fmt.Sprintf("%.1f%%", a.Accuracy), fmt.Sprintf("%.1f%%", b.Accuracy), compareFloat(a.Accuracy, b.Accuracy))
+	addRow("Correct", fmt.Sprint(a.Correct), fmt.Sprint(b.Correct), a.Correct-b.Correct)
+	addRow("Errors", fmt.Sprint(a.Errors), fmt.Sprint(b.Errors), b.Errors-a.Errors)
+	addRow("Extra", fmt.Sprint(a.Extra), fmt.Sprint(b.Extra), b.Extra-a.Extra)
+
+	var menuItems []string
+	for i, option := range duelOptions() {
+		cursor := " "
+		style := EndGameOptionStyle
+		if m.selectedItem == i {
+			cursor = ">"
+			style = EndGameSelectedOptionStyle
+		}
+		menuItems = append(menuItems, style.Render(fmt.Sprintf("%s %s", cursor, option)))
+	}
+
+	return lipgloss.NewStyle().
+		Width(m.width * 3 / 4).
+		Align(lipgloss.Center).
+		Render(
+			"\n" +
+				title + "\n\n" +
+				winnerText + "\n\n" +
+				lipgloss.NewStyle().Align(lipgloss.Left).Render(strings.Join(rows, "\n")) + "\n\n" +
+				strings.Join(menuItems, "\n") + "\n\n" +
+				HelpStyle("Use arrow keys to navigate, enter to select, esc to quit"),
+		)
+}
+
+// compareResults ranks two results by WPM, then accuracy: 1 if a is
+// better, -1 if b is, 0 on a tie.
+func compareResults(a, b GameResult) int {
+	if c := compareFloat(a.WPM, b.WPM); c != 0 {
+		return c
+	}
+	return compareFloat(a.Accuracy, b.Accuracy)
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+	return 0
+}
+
+// RunDuel starts a hot-seat duel on text, a new text of the active game
+// mode if text is empty.
+func RunDuel(text string, names [2]string) {
+	if text != "" {
+		text = GameText(text)
+	} else {
+		text = duelMode().GenerateText(CurrentSettings)
+	}
+
+	p := tea.NewProgram(NewDuelModel(0, 0, text, names), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error running duel: %v\n", err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/race"
 	"strings"
 	"time"
 )
@@ -17,11 +19,42 @@ type EndGameModel struct {
 	words        int
 	correct      int
 	errors       int
+	extra        int
 	text         string
+	layout       string   // name of the emulated keyboard layout, empty if none
+	mode         GameMode // mode the game was played in, kept for replays
+	lesson       int      // index into Lessons, -1 outside the curriculum
+	lessonPassed bool     // whether the lesson's pass criteria were met
 	startTime    time.Time
 	lastTick     time.Time
+	errorPolicy  ErrorPolicy
+
+	backspacePolicy BackspacePolicy
+
+	race    []race.Racer // rankings of a network race, nil outside races
+	racerID int          // the player's ID in the race
+
+	watching  bool // showing someone else's broadcast game
+	classroom bool // an instructor's assignment, more rounds may follow
+
+	bots []race.Racer // rankings against bot racers, the player is ID 0
+
+	daily *dailySummary // the day's result and streak, nil outside the daily
+
+	challenge       *Challenge // challenge being played, nil outside challenges
+	challengePassed bool       // whether the challenge's criteria were met
+
+	ranked       bool       // a solo game, see submittable
+	log          *ResultLog // the game with its keystroke log, nil for games watched from afar
+	submitted    bool       // the result was sent to the leaderboard
+	submitStatus string     // outcome of the last submission, shown under the stats
 }
 
+const (
+	optionSubmit      = "Submit to Leaderboard"
+	optionLeaderboard = "View Leaderboard"
+)
+
 func NewEndGameModel(wpm, accuracy float64, words, correct, errors int, text string) *EndGameModel {
 	return &EndGameModel{
 		selectedItem: 0,
@@ -31,6 +64,7 @@ func NewEndGameModel(wpm, accuracy float64, words, correct, errors int, text str
 		correct:      correct,
 		errors:       errors,
 		text:         text,
+		lesson:       -1,
 		startTime:    time.Now(),
 		lastTick:     time.Now(),
 	}
@@ -47,6 +81,21 @@ func (m *EndGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
 		return m, cmd
 
+	case leaderboardSubmittedMsg:
+		if msg.err != nil {
+			devlog.Log("Leaderboard: %v", msg.err)
+			m.submitStatus = ErrorStyle.Render("Could not submit: " + msg.err.Error())
+			return m, nil
+		}
+		m.submitted = true
+		m.submitStatus = HintStyle(fmt.Sprintf("Submitted to the leaderboard as %s", msg.entry.User))
+		// NOTE: the server keeps results that don't check out off the leaderboard
+		if len(msg.entry.Flags) > 0 {
+			m.submitStatus = ErrorStyle.Render("Kept off the leaderboard: " + msg.entry.Flags[0])
+		}
+		m.selectedItem = min(m.selectedItem, len(m.options())-1)
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -55,23 +104,51 @@ func (m *EndGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "up", "k":
 			m.selectedItem--
 			if m.selectedItem < 0 {
-				m.selectedItem = 1
+				m.selectedItem = len(m.options()) - 1
 			}
 			return m, nil
 
 		case "down", "j":
 			m.selectedItem++
-			if m.selectedItem > 1 {
+			if m.selectedItem >= len(m.options()) {
 				m.selectedItem = 0
 			}
 			return m, nil
 
 		case "enter", " ":
+			if m.lesson >= 0 {
+				return m.selectLessonOption()
+			}
+			if m.daily != nil {
+				return m.selectDailyOption()
+			}
+			if m.challenge != nil {
+				return m.selectChallengeOption()
+			}
+
+			switch m.options()[m.selectedItem] {
+			case optionSubmit:
+				return m, m.submit()
+			case optionLeaderboard:
+				mode := ""
+				if m.mode != nil {
+					mode = m.mode.Name()
+				}
+				board := NewLeaderboardModel(m.width, m.height, CurrentSettings.LeaderboardURL, mode)
+				board.back = m
+				return board, board.Init()
+			}
+
 			switch m.selectedItem {
 			case 0:
-				return NewTypingModel(m.width, m.height, m.text), InitGlobalTick()
+				model := NewTypingModel(m.width, m.height, m.text)
+				if m.mode != nil {
+					model.mode = m.mode
+				}
+				model.ranked = m.ranked
+				return model, InitGlobalTick()
 			case 1:
-				StartLoadingWithOptions(CurrentSettings.CursorType, m.text)
+				StartLoadingWithOptions(CurrentSettings.CursorType, "")
 				return m, tea.Quit
 			}
 
@@ -112,11 +189,48 @@ func (m *EndGameModel) View() string {
 	stats := fmt.Sprintf("%s   %s   %s   %s   %s",
 		wpmText, accuracyText, wordsText, correctText, errorsText)
 
-	options := []string{
-		"Play with Same Text",
-		"Play with New Text",
+	if m.extra > 0 {
+		stats += "   " + RenderGradientOverlay(fmt.Sprintf("Extra: %d", m.extra), errorsStyle, m.lastTick)
+	}
+
+	if m.errorPolicy != "" {
+		stats += "\n\n" + HelpStyle(fmt.Sprintf("Error policy: %s • Backspace: %s", m.errorPolicy.Title(), m.backspacePolicy.Title()))
+	}
+
+	if m.layout != "" {
+		stats += "\n\n" + HelpStyle(fmt.Sprintf("Typed with emulated %s layout", m.layout))
+	}
+
+	if m.lesson >= 0 {
+		stats += "\n\n" + m.renderLessonResult()
+	}
+
+	if m.daily != nil {
+		stats += "\n\n" + m.renderDailyResult()
+	}
+
+	if m.challenge != nil {
+		stats += "\n\n" + m.renderChallengeResult()
+	}
+
+	if m.race != nil {
+		stats += "\n\n" + renderRankings(m.race, m.racerID)
+	}
+
+	if m.bots != nil {
+		stats += "\n\n" + renderRankings(m.bots, 0)
+	}
+
+	if m.classroom {
+		stats += "\n\n" + HintStyle("Result sent to the instructor, the next round starts on its own.")
 	}
 
+	if m.submitStatus != "" {
+		stats += "\n\n" + m.submitStatus
+	}
+
+	options := m.options()
+
 	var menuItems []string
 	for i, option := range options {
 		cursor := " "
@@ -145,3 +259,86 @@ func (m *EndGameModel) View() string {
 		lipgloss.Center, lipgloss.Center,
 		content)
 }
+
+// submittable reports whether the result goes on the leaderboard and is kept
+// for verify: a solo game without bots.
+func (m *EndGameModel) submittable() bool {
+	return m.ranked && len(m.bots) == 0 && m.log != nil
+}
+
+func (m *EndGameModel) options() []string {
+	if m.race != nil {
+		return []string{"Leave Race"}
+	}
+
+	if m.watching {
+		return []string{"Stop Watching"}
+	}
+
+	if m.classroom {
+		return []string{"Leave Class"}
+	}
+
+	if m.daily != nil {
+		return []string{"Practice Same Text", "Quit"}
+	}
+
+	if m.challenge != nil {
+		return []string{"Retry Challenge", "Quit"}
+	}
+
+	if m.lesson < 0 {
+		options := []string{
+			"Play with Same Text",
+			"Play with New Text",
+		}
+		if CurrentSettings.LeaderboardURL != "" {
+			if !m.submitted && m.submittable() {
+				options = append(options, optionSubmit)
+			}
+			options = append(options, optionLeaderboard)
+		}
+		return options
+	}
+
+	options := []string{"Retry Same Drill", "Retry with New Drill"}
+	if m.lessonPassed && m.lesson+1 < len(Lessons) {
+		options = append(options, "Next Lesson")
+	}
+	return options
+}
+
+func (m *EndGameModel) selectLessonOption() (tea.Model, tea.Cmd) {
+	var model *TypingModel
+
+	switch m.selectedItem {
+	case 0:
+		model = NewTypingModel(m.width, m.height, m.text)
+		model.mode = &lessonMode{index: m.lesson}
+	case 1:
+		model = NewLessonModel(m.width, m.height, m.lesson)
+	case 2:
+		model = NewLessonModel(m.width, m.height, m.lesson+1)
+	default:
+		return m, nil
+	}
+
+	return model, InitGlobalTick()
+}
+
+func (m *EndGameModel) renderLessonResult() string {
+	lesson := Lessons[m.lesson]
+	criteria := fmt.Sprintf("needs %.0f WPM and %.0f%% accuracy", lesson.MinWPM, lesson.MinAccuracy)
+
+	if m.lessonPassed {
+		return lipgloss.NewStyle().
+			Foreground(GetColor("text_correct")).
+			Bold(true).
+			Render(fmt.Sprintf("Lesson %d passed!", m.lesson+1)) + " " + HelpStyle("("+criteria+")")
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(GetColor("text_error")).
+		Bold(true).
+		Render(fmt.Sprintf("Lesson %d not passed yet", m.lesson+1)) + " " + HelpStyle("("+criteria+")")
+}
@@ -2,13 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	devlog "github.com/prime-run/go-typer/log"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
+	"github.com/prime-run/go-typer/race"
 )
 
 type TypingModel struct {
@@ -22,6 +22,21 @@ type TypingModel struct {
 	needsRefresh bool
 	gameComplete bool
 	lastTick     time.Time
+	layout       *KeyboardLayout
+	flashKey     string    // last mistyped key, flashed on the keyboard
+	flashUntil   time.Time // when the flash on the keyboard ends
+	mode         GameMode
+	keystrokes   []Keystroke // everything typed, in order
+	banner       string      // shown under the passage, like the standings of a race
+	announced    bool        // the passage was sent to the broadcast watchers
+
+	bots []*Bot // bot racers typing the same passage
+
+	timeLimit time.Duration // limit of a timed game, from the settings at the start
+
+	language string // name of the language pack, loaded once for the HUD
+
+	ranked bool // a solo game: its result is kept for verify and can be submitted
 }
 
 func NewTypingModel(width, height int, text string) *TypingModel {
@@ -34,9 +49,37 @@ func NewTypingModel(width, height int, text string) *TypingModel {
 		needsRefresh: true,
 		lastKeyTime:  time.Now(),
 		lastTick:     time.Now(),
+		mode:         ActiveGameMode(),
+		timeLimit:    time.Duration(timeLimit(CurrentSettings)) * time.Second,
 	}
+	// NOTE: a game sticks to its lesson, the HUD would follow the saved
+	// progress otherwise
+	if lesson, ok := model.mode.(*lessonMode); ok && lesson.index < 0 {
+		model.mode = &lessonMode{index: currentLessonIndex()}
+	}
+	layout, err := LoadKeyboardLayout(CurrentSettings.KeyboardLayout)
+	if err != nil {
+		devlog.Log("Game: Could not load keyboard layout %s: %v", CurrentSettings.KeyboardLayout, err)
+	}
+	model.layout = layout
+
 	model.text = NewText(text)
 	model.text.SetCursorType(DefaultCursorType)
+	model.text.SetErrorPolicy(ParseErrorPolicy(CurrentSettings.ErrorPolicy))
+	model.text.SetBackspacePolicy(ParseBackspacePolicy(CurrentSettings.BackspacePolicy))
+
+	model.language = CurrentSettings.Language
+	pack, err := LoadLanguagePack(CurrentSettings.Language)
+	if err != nil {
+		devlog.Log("Game: Could not load language pack %s: %v", CurrentSettings.Language, err)
+	} else {
+		model.language = pack.Name
+		// NOTE: the pack's direction is for passages without any strong directional character
+		if model.text.Direction() == DirectionAuto {
+			model.text.SetDirection(pack.Direction)
+		}
+	}
+	model.bots = newBots(text)
 	return model
 }
 
@@ -46,17 +89,19 @@ func (m *TypingModel) Init() tea.Cmd {
 }
 
 func (m *TypingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if !m.announced {
+		m.broadcastSession()
+	}
+
 	switch msg := msg.(type) {
 	case GlobalTickMsg:
 
 		var cmd tea.Cmd
 		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
+		m.advanceBots()
 
-		if !m.gameComplete && m.text.GetCursorPos() == len(m.text.words)-1 {
-			lastWord := m.text.words[m.text.GetCursorPos()]
-			if lastWord.IsComplete() {
-				return m.handleGameCompletion()
-			}
+		if !m.gameComplete && m.mode.IsComplete(m.state()) {
+			return m.handleGameCompletion()
 		}
 
 		return m, cmd
@@ -67,36 +112,76 @@ func (m *TypingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// NOTE: bracketed paste arrives as one KeyRunes burst, typing
+		// practice doesn't make sense on pasted text so it's dropped
+		if msg.Paste {
+			devlog.Log("Game: Ignoring pasted input (%d runes)", len(msg.Runes))
+			return m, nil
+		}
+
 		m.lastKeyTime = time.Now()
 
 		keyStr := msg.String()
 		devlog.Log("Game: Key pressed: %s", keyStr)
 
+		if msg.Type == tea.KeyCtrlK {
+			CurrentSettings.ShowKeyboard = !CurrentSettings.ShowKeyboard
+			if err := SaveSettings(); err != nil {
+				devlog.Log("Game: Could not save keyboard setting: %v", err)
+			}
+			return m, nil
+		}
+
 		if !m.timerRunning && keyStr != "tab" && keyStr != "esc" && keyStr != "ctrl+c" {
 			m.timerRunning = true
 			m.startTime = time.Now()
+			if starter, ok := m.mode.(gameStarter); ok {
+				starter.Start()
+			}
+		}
+
+		if isWordDeleteKey(keyStr) {
+			m.text.DeleteWord()
+			m.record(ActionDeleteWord, "")
+			return m, nil
 		}
 
 		switch msg.Type {
 		case tea.KeyCtrlC, tea.KeyEsc:
 			return m, tea.Quit
 		case tea.KeyTab:
+			if mode, ok := m.mode.(*challengeMode); ok {
+				return newChallengeGame(m.width, m.height, m.text.GetText(), mode), InitGlobalTick()
+			}
 
 			newModel := NewTypingModel(m.width, m.height, m.text.GetText())
+			newModel.mode = m.mode
+			newModel.ranked = m.ranked
 			return newModel, InitGlobalTick()
-		case tea.KeyBackspace:
+		// NOTE: some terminals send ^H for Backspace
+		case tea.KeyBackspace, tea.KeyCtrlH:
 			m.text.Backspace()
-		default:
-			if len(keyStr) == 1 {
-				m.text.Type([]rune(keyStr)[0])
-
-				if m.text.GetCursorPos() == len(m.text.words)-1 {
-					lastWord := m.text.words[m.text.GetCursorPos()]
-					if lastWord.IsComplete() {
-						return m.handleGameCompletion()
-					}
+			m.record(ActionBackspace, "")
+		case tea.KeySpace, tea.KeyRunes:
+			if msg.Alt {
+				return m, nil
+			}
+
+			// NOTE: a single event can carry several runes (IME commits,
+			// compose sequences, fast typing coalesced by the terminal)
+			runes := m.layout.Remap(msg.Runes)
+			if len(runes) > 0 {
+				if typed := string(runes[0]); typed != m.text.NextGrapheme() {
+					m.flashKey = typed
+					m.flashUntil = time.Now().Add(keyboardFlashDuration)
 				}
 			}
+			m.text.TypeRunes(runes)
+			m.record(ActionType, string(runes))
+
+			if m.mode.IsComplete(m.state()) {
+				return m.handleGameCompletion()
+			}
 		}
 
 		return m, nil
@@ -110,36 +195,116 @@ func (m *TypingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *TypingModel) handleGameCompletion() (tea.Model, tea.Cmd) {
-	total, correct, errors := m.text.Stats()
-	accuracy := 0.0
-	if total > 0 {
-		accuracy = float64(correct) / float64(total) * 100
+// startClock starts the game's clock at t instead of at the first key, so
+// everyone in a race is timed from the same start.
+func (m *TypingModel) startClock(t time.Time) {
+	m.timerRunning = true
+	m.startTime = t
+	m.lastTick = t
+}
+
+// record appends a keystroke to the game's log.
+func (m *TypingModel) record(action KeyAction, text string) {
+	keystroke := Keystroke{Action: action, Text: text}
+	if m.timerRunning {
+		keystroke.At = time.Since(m.startTime)
+	}
+	m.keystrokes = append(m.keystrokes, keystroke)
+	m.broadcastKeystroke(keystroke)
+	devlog.Log("Game: Keystroke %s %q at %s", action, text, keystroke.At)
+}
+
+// advanceBots lets the bot racers catch up with the game's clock and shows
+// where they are.
+func (m *TypingModel) advanceBots() {
+	if len(m.bots) == 0 || m.gameComplete {
+		return
+	}
+	state := m.state()
+	if state.Started {
+		for _, bot := range m.bots {
+			bot.Advance(state.Elapsed)
+		}
 	}
+	m.text.SetCarets(botCarets(m.bots))
+	m.banner = renderStandings(m.botStandings(false), 0)
+}
 
-	elapsedMinutes := m.lastTick.Sub(m.startTime).Minutes()
+// botStandings ranks the player against the bot racers.
+func (m *TypingModel) botStandings(finished bool) []race.Racer {
+	state := m.state()
+	player := race.Racer{Name: "You", Progress: m.text.Progress(), Finished: finished}
+	if finished {
+		result := m.mode.Score(state)
+		player.WPM, player.Accuracy = result.WPM, result.Accuracy
+	} else {
+		total, correct, errors := m.text.TypedStats()
+		player.WPM = scoreWords(total, correct, errors, state.Elapsed).WPM
+	}
+	return botStandings(m.bots, player, state.Elapsed)
+}
 
-	wpm := 0.0
-	if elapsedMinutes > 0 {
-		wpm = float64(correct*5) / elapsedMinutes / 5
+// state is the view of the game handed to the game mode.
+func (m *TypingModel) state() GameState {
+	state := GameState{Text: m.text, Started: m.timerRunning, TimeLimit: m.timeLimit}
+	if m.timerRunning {
+		state.Elapsed = m.lastTick.Sub(m.startTime)
 	}
+	return state
+}
 
-	endModel := NewEndGameModel(wpm, accuracy, total, correct, errors, m.text.GetText())
+func (m *TypingModel) handleGameCompletion() (tea.Model, tea.Cmd) {
+	result := m.mode.Score(m.state())
+	result.Extra = m.text.ExtraLetters()
+	result.Keystrokes = m.keystrokes
+	result.ErrorPolicy = m.text.ErrorPolicy()
+	result.BackspacePolicy = m.text.BackspacePolicy()
+	m.broadcastEnd(result)
+
+	endModel := NewEndGameModel(result.WPM, result.Accuracy, result.Words, result.Correct, result.Errors, m.text.GetText())
 	endModel.width = m.width
 	endModel.height = m.height
+	endModel.mode = m.mode
+	endModel.extra = result.Extra
+	endModel.log = m.resultLog(result)
+	endModel.errorPolicy = result.ErrorPolicy
+	endModel.backspacePolicy = result.BackspacePolicy
+	endModel.ranked = m.ranked
+	if m.layout.IsEmulated() {
+		endModel.layout = m.layout.Name
+	}
+	if len(m.bots) > 0 {
+		endModel.bots = m.botStandings(true)
+	}
+	if finisher, ok := m.mode.(gameFinisher); ok {
+		finisher.Finish(result, endModel)
+	}
+	// NOTE: duel turns, races, lessons and the like would overwrite the
+	// last result that can actually be verified or submitted
+	if endModel.submittable() {
+		if err := SaveLastResult(*endModel.log); err != nil {
+			devlog.Log("Game: Could not save the result: %v", err)
+		}
+	}
 	return endModel, InitGlobalTick()
 }
 
-func (m *TypingModel) formatElapsedTime() string {
-	if !m.timerRunning {
-		return "00:00"
+// resultLog is the finished game with its keystroke log, for verification.
+func (m *TypingModel) resultLog(result GameResult) *ResultLog {
+	log := &ResultLog{
+		Text:            m.text.GetText(),
+		Mode:            m.mode.Name(),
+		WPM:             result.WPM,
+		Accuracy:        result.Accuracy,
+		Elapsed:         m.state().Elapsed,
+		ErrorPolicy:     string(result.ErrorPolicy),
+		BackspacePolicy: string(result.BackspacePolicy),
+		Keystrokes:      result.Keystrokes,
 	}
-
-	elapsed := m.lastTick.Sub(m.startTime)
-	minutes := int(elapsed.Minutes())
-	seconds := int(elapsed.Seconds()) % 60
-
-	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+	if m.mode.Name() == GameModeTimed {
+		log.TimeLimit = m.timeLimit
+	}
+	return log
 }
 
 func (m *TypingModel) View() string {
@@ -161,9 +326,24 @@ func (m *TypingModel) View() string {
 		cursorType = "Block cursor"
 	}
 
-	modeInfo := cases.Title(language.English).String(CurrentSettings.GameMode) + " mode"
-	if CurrentSettings.UseNumbers {
+	modeInfo := m.mode.Title() + " mode"
+	specs := strings.Join(m.mode.Pipeline(CurrentSettings), " ")
+	switch numbers, punctuation := strings.Contains(specs, "inject_numbers"), strings.Contains(specs, "inject_punctuation"); {
+	case numbers && punctuation:
+		modeInfo += " with numbers and punctuation"
+	case numbers:
 		modeInfo += " with numbers"
+	case punctuation:
+		modeInfo += " with punctuation"
+	}
+	if policy := m.text.ErrorPolicy(); policy != ErrorPolicyFree {
+		modeInfo += " • " + strings.ToLower(policy.Title())
+	}
+	if policy := m.text.BackspacePolicy(); policy != BackspaceFree {
+		modeInfo += " • backspace: " + strings.ToLower(policy.Title())
+	}
+	if m.layout.IsEmulated() {
+		modeInfo += " • " + m.layout.Name + " layout"
 	}
 
 	lengthMap := map[string]string{
@@ -175,17 +355,37 @@ func (m *TypingModel) View() string {
 
 	// FIX:? Render the complete view in one go
 	//LOL Bug or feature! i really don't konow what to call it!
-	content := lipgloss.NewStyle().
-		Width(m.width * 3 / 4).
-		Align(lipgloss.Center).
-		Render(fmt.Sprintf(
-			"\nGoTyper - Typing Practice %s\n\n%s\n\n%s\n\n%s\n%s",
-			TimerStyle.Render(m.formatElapsedTime()),
-			textContent,
-			HintStyle("◾ Type the text above. results would pop when you are done typing.\n◾ Timer will start as soon as you press the first key.\n◾ Paragraph's lenght, gameplay and alot more can be adjusted in settings.\n◾ Press ESC to quit, TAB to reset current passage."),
-			SettingsStyle("Current Settings:"),
-			HelpStyle(fmt.Sprintf(" • %s • %s • %s", cursorType, modeInfo, lengthMap[CurrentSettings.TextLength])),
-		))
+	render := func(body string) string {
+		return lipgloss.NewStyle().
+			Width(m.width * 3 / 4).
+			Align(lipgloss.Center).
+			Render(fmt.Sprintf(
+				"\n%s\n\n%s\n\n%s\n\n%s\n%s",
+				renderModeHUD(m.mode, m.state()),
+				body,
+				HintStyle("◾ Type the text above. results would pop when you are done typing.\n◾ Timer will start as soon as you press the first key.\n◾ Paragraph's lenght, gameplay and alot more can be adjusted in settings.\n◾ Press ESC to quit, TAB to reset current passage, Ctrl+K to toggle the keyboard."),
+				SettingsStyle("Current Settings:"),
+				HelpStyle(fmt.Sprintf(" • %s • %s • %s • %s", cursorType, modeInfo, lengthMap[CurrentSettings.TextLength], m.language)),
+			))
+	}
+
+	if m.banner != "" {
+		textContent += "\n\n" + m.banner
+	}
+
+	content := render(textContent)
+
+	// NOTE: the keyboard is dropped when it doesn't fit instead of squashing the passage
+	if CurrentSettings.ShowKeyboard && !m.gameComplete && m.width >= keyboardMinWidth {
+		flash := ""
+		if time.Now().Before(m.flashUntil) {
+			flash = m.flashKey
+		}
+		keyboard := RenderKeyboard(m.layout, m.text.NextGrapheme(), flash)
+		if withKeyboard := render(textContent + "\n\n" + keyboard); lipgloss.Height(withKeyboard) <= m.height {
+			content = withKeyboard
+		}
+	}
 
 	result := lipgloss.Place(m.width, m.height,
 		lipgloss.Center, lipgloss.Center,
@@ -202,6 +402,7 @@ func StartTypingGame(width, height int, text string) tea.Model {
 
 	startTime := time.Now()
 	model := NewTypingModel(width, height, text)
+	model.ranked = true
 	initTime := time.Since(startTime)
 
 	devlog.Log("Game: Model initialization completed in %s", initTime)
@@ -0,0 +1,461 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/utils"
+)
+
+const (
+	GameModeNormal  = "normal"  // Quotes with punctuation
+	GameModeSimple  = "simple"  // Quotes, lowercase without punctuation
+	GameModeTimed   = "timed"   // Common words against the clock
+	GameModeWords   = "words"   // A fixed number of common words
+	GameModeZen     = "zen"     // Common words, no timer shown
+	GameModeLessons = "lessons" // Drills from the touch typing curriculum
+	GameModeCode    = "code"    // Source code snippets
+
+	timedModeWPM = 250 // Fastest typing a timed game has words for, so the clock runs out before the text
+	zenModeWords = 60  // Words generated for a zen game
+)
+
+// GameState is what a game mode gets to see of a running game.
+type GameState struct {
+	Text    *Text
+	Started bool          // the first key has been pressed
+	Elapsed time.Duration // time since the first key
+
+	TimeLimit time.Duration // limit of a timed game, fixed when the game starts
+}
+
+// GameResult is the score of a finished game.
+type GameResult struct {
+	WPM      float64
+	Accuracy float64
+	Words    int
+	Correct  int
+	Errors   int
+	Extra    int // letters typed past the end of words
+
+	ErrorPolicy     ErrorPolicy     // policy the game was played with
+	BackspacePolicy BackspacePolicy // how far backspace could walk back
+
+	Keystrokes []Keystroke // the game's keystroke log
+}
+
+// GameMode owns everything that differs between modes: where the text comes
+// from, when the game is over, how it is scored and what it shows while
+// playing. Modes are registered with RegisterGameMode.
+type GameMode interface {
+	Name() string        // key stored in the settings
+	Title() string       // display name
+	Description() string // one line shown in the settings menu
+
+	// Pipeline lists the text transforms applied to the mode's text.
+	Pipeline(settings UserSettings) []string
+	// GenerateText returns the passage for a new game.
+	GenerateText(settings UserSettings) string
+	IsComplete(state GameState) bool
+	Score(state GameState) GameResult
+	// HUD is shown next to the title while playing (timer, progress, ...).
+	HUD(state GameState) string
+}
+
+// gameStarter is implemented by modes that act when a game starts, on its
+// first key, like taking up the daily's scored attempt.
+type gameStarter interface {
+	Start()
+}
+
+// gameFinisher is implemented by modes that act on a finished game before
+// the results are shown, like recording lesson progress.
+type gameFinisher interface {
+	Finish(result GameResult, end *EndGameModel)
+}
+
+var gameModes []GameMode
+
+// RegisterGameMode adds a mode to the registry, replacing a mode with the same name.
+func RegisterGameMode(mode GameMode) {
+	for i, registered := range gameModes {
+		if registered.Name() == mode.Name() {
+			gameModes[i] = mode
+			return
+		}
+	}
+	gameModes = append(gameModes, mode)
+}
+
+// GameModes returns the registered modes in registration order.
+func GameModes() []GameMode {
+	return gameModes
+}
+
+// LookupGameMode returns the mode registered under name, normal mode if there is none.
+func LookupGameMode(name string) GameMode {
+	for _, mode := range gameModes {
+		if mode.Name() == name {
+			return mode
+		}
+	}
+	return gameModes[0]
+}
+
+// ActiveGameMode returns the mode selected in the settings.
+func ActiveGameMode() GameMode {
+	return LookupGameMode(CurrentSettings.GameMode)
+}
+
+// GameModeNames returns the names of the registered modes.
+func GameModeNames() []string {
+	names := make([]string, len(gameModes))
+	for i, mode := range gameModes {
+		names[i] = mode.Name()
+	}
+	return names
+}
+
+func init() {
+	RegisterGameMode(&quoteMode{
+		name:        GameModeNormal,
+		title:       "Normal",
+		description: "Quotes with punctuation and capitals",
+		pipeline:    []string{"strip_untypeable", "collapse_whitespace", "max_words:100"},
+	})
+	RegisterGameMode(&quoteMode{
+		name:        GameModeSimple,
+		title:       "Simple",
+		description: "Quotes in lowercase without punctuation",
+		pipeline:    []string{"strip_untypeable", "strip_punctuation", "lowercase", "collapse_whitespace", "max_words:100"},
+	})
+	RegisterGameMode(&timedMode{})
+	RegisterGameMode(&wordsMode{})
+	RegisterGameMode(&zenMode{})
+	RegisterGameMode(&lessonMode{index: -1})
+	RegisterGameMode(&codeMode{})
+}
+
+// modePipeline returns the user's pipeline for the mode if one is configured.
+func modePipeline(settings UserSettings, name string, defaults []string) []string {
+	if specs, ok := settings.Pipelines[name]; ok {
+		return specs
+	}
+	return defaults
+}
+
+// withInjection appends the number and punctuation transforms the settings
+// ask for, used by the word list modes.
+func withInjection(settings UserSettings, specs []string) []string {
+	specs = append([]string(nil), specs...)
+	if settings.UseNumbers {
+		specs = append(specs, fmt.Sprintf("inject_numbers:%g", settings.NumberRate))
+	}
+	if settings.UsePunctuation {
+		specs = append(specs, fmt.Sprintf("inject_punctuation:%g", settings.PunctuationRate))
+	}
+	return specs
+}
+
+// finishedText is the usual completion condition: the last word is typed.
+func finishedText(state GameState) bool {
+	return state.Text.Finished()
+}
+
+// scoreWords computes WPM and accuracy word by word.
+func scoreWords(total, correct, errors int, elapsed time.Duration) GameResult {
+	result := GameResult{Words: total, Correct: correct, Errors: errors}
+	if total > 0 {
+		result.Accuracy = float64(correct) / float64(total) * 100
+	}
+	if minutes := elapsed.Minutes(); minutes > 0 {
+		result.WPM = float64(correct*5) / minutes / 5
+	}
+	return result
+}
+
+func scoreText(state GameState) GameResult {
+	total, correct, errors := state.Text.Stats()
+	return scoreWords(total, correct, errors, state.Elapsed)
+}
+
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	seconds := int(d.Seconds()) % 60
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+// elapsedHUD shows the running timer, the HUD of most modes.
+func elapsedHUD(state GameState) string {
+	if !state.Started {
+		return TimerStyle.Render("00:00")
+	}
+	return TimerStyle.Render(formatDuration(state.Elapsed))
+}
+
+// randomWords samples n words from the current language pack.
+func randomWords(settings UserSettings, n int) string {
+	pack, err := LoadLanguagePack(settings.Language)
+	if err != nil || len(pack.Words) == 0 {
+		devlog.Log("GameMode: Language pack %s has no words, using %s: %v", settings.Language, DefaultLanguage, err)
+		pack, err = LoadLanguagePack(DefaultLanguage)
+		if err != nil {
+			return "The quick brown fox jumps over the lazy dog."
+		}
+	}
+	return strings.Join(pack.RandomWords(n), " ")
+}
+
+// quoteMode types quotes from the online sources and language packs, the
+// text length setting decides how many. Each quote goes through the pipeline
+// of the settings it was generated for.
+type quoteMode struct {
+	name, title, description string
+	pipeline                 []string
+}
+
+func (m *quoteMode) Name() string        { return m.name }
+func (m *quoteMode) Title() string       { return m.title }
+func (m *quoteMode) Description() string { return m.description }
+
+// NOTE: quotes are prose already, numbers and punctuation are only injected
+// into word lists
+func (m *quoteMode) Pipeline(settings UserSettings) []string {
+	return modePipeline(settings, m.name, m.pipeline)
+}
+
+func (m *quoteMode) GenerateText(settings UserSettings) string {
+	textCount := map[string]int{
+		TextLengthShort:    1,
+		TextLengthMedium:   2,
+		TextLengthLong:     3,
+		TextLengthVeryLong: 5,
+	}
+
+	count := max(textCount[settings.TextLength], 1)
+
+	texts := make([]string, 0, count)
+	for range count {
+		texts = append(texts, GetRandomText(settings))
+	}
+
+	return strings.Join(texts, " ")
+}
+
+func (m *quoteMode) IsComplete(state GameState) bool  { return finishedText(state) }
+func (m *quoteMode) Score(state GameState) GameResult { return scoreText(state) }
+func (m *quoteMode) HUD(state GameState) string       { return elapsedHUD(state) }
+
+// timedMode types common words until the time limit runs out.
+type timedMode struct{}
+
+func (m *timedMode) Name() string  { return GameModeTimed }
+func (m *timedMode) Title() string { return "Timed" }
+func (m *timedMode) Description() string {
+	return fmt.Sprintf("Common words against the clock (%ds)", timeLimit(CurrentSettings))
+}
+
+func (m *timedMode) Pipeline(settings UserSettings) []string {
+	return withInjection(settings, modePipeline(settings, GameModeTimed, []string{"strip_untypeable", "collapse_whitespace"}))
+}
+
+func (m *timedMode) GenerateText(settings UserSettings) string {
+	return TextPipeline(settings).Apply(randomWords(settings, timedWords(settings)))
+}
+
+// timedWords is how many words a timed game needs to last its time limit.
+func timedWords(settings UserSettings) int {
+	return timeLimit(settings) * timedModeWPM / 60
+}
+
+func (m *timedMode) IsComplete(state GameState) bool {
+	return state.Elapsed >= timedLimit(state) || finishedText(state)
+}
+
+// Score only counts the words typed before the time ran out.
+func (m *timedMode) Score(state GameState) GameResult {
+	total, correct, errors := state.Text.TypedStats()
+	elapsed := state.Elapsed
+	if limit := timedLimit(state); elapsed > limit {
+		elapsed = limit
+	}
+	return scoreWords(total, correct, errors, elapsed)
+}
+
+func (m *timedMode) HUD(state GameState) string {
+	remaining := timedLimit(state)
+	if state.Started {
+		remaining -= state.Elapsed
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return TimerStyle.Render(formatDuration(remaining))
+}
+
+// timedLimit is the time limit of a timed game, the default one for states
+// that don't carry a limit.
+func timedLimit(state GameState) time.Duration {
+	if state.TimeLimit > 0 {
+		return state.TimeLimit
+	}
+	return time.Duration(DefaultSettings.TimeLimit) * time.Second
+}
+
+func timeLimit(settings UserSettings) int {
+	if settings.TimeLimit <= 0 {
+		return DefaultSettings.TimeLimit
+	}
+	return settings.TimeLimit
+}
+
+// wordsMode types a fixed number of common words.
+type wordsMode struct{}
+
+func (m *wordsMode) Name() string  { return GameModeWords }
+func (m *wordsMode) Title() string { return "Words" }
+func (m *wordsMode) Description() string {
+	return fmt.Sprintf("A fixed number of common words (%d)", wordCount(CurrentSettings))
+}
+
+func (m *wordsMode) Pipeline(settings UserSettings) []string {
+	return withInjection(settings, modePipeline(settings, GameModeWords, []string{"strip_untypeable", "collapse_whitespace"}))
+}
+
+func (m *wordsMode) GenerateText(settings UserSettings) string {
+	return TextPipeline(settings).Apply(randomWords(settings, wordCount(settings)))
+}
+
+func (m *wordsMode) IsComplete(state GameState) bool  { return finishedText(state) }
+func (m *wordsMode) Score(state GameState) GameResult { return scoreText(state) }
+
+func (m *wordsMode) HUD(state GameState) string {
+	typed, _, _ := state.Text.TypedStats()
+	total, _, _ := state.Text.Stats()
+	return elapsedHUD(state) + " " + HelpStyle(fmt.Sprintf("%d/%d", typed, total))
+}
+
+func wordCount(settings UserSettings) int {
+	if settings.WordCount <= 0 {
+		return DefaultSettings.WordCount
+	}
+	return settings.WordCount
+}
+
+// zenMode hides the clock, results are only shown at the end.
+type zenMode struct{}
+
+func (m *zenMode) Name() string        { return GameModeZen }
+func (m *zenMode) Title() string       { return "Zen" }
+func (m *zenMode) Description() string { return "Common words without a timer on screen" }
+
+func (m *zenMode) Pipeline(settings UserSettings) []string {
+	return withInjection(settings, modePipeline(settings, GameModeZen, []string{"strip_untypeable", "lowercase", "collapse_whitespace"}))
+}
+
+func (m *zenMode) GenerateText(settings UserSettings) string {
+	return TextPipeline(settings).Apply(randomWords(settings, zenModeWords))
+}
+
+func (m *zenMode) IsComplete(state GameState) bool  { return finishedText(state) }
+func (m *zenMode) Score(state GameState) GameResult { return scoreText(state) }
+func (m *zenMode) HUD(state GameState) string       { return "" }
+
+// lessonMode plays a lesson of the touch typing curriculum. The registered
+// mode (index -1) always plays the first lesson that hasn't been passed, a
+// game resolves it to that lesson when it starts.
+type lessonMode struct {
+	index int
+}
+
+func (m *lessonMode) Name() string  { return GameModeLessons }
+func (m *lessonMode) Title() string { return "Lessons" }
+func (m *lessonMode) Description() string {
+	return "Drills that unlock the keyboard a few keys at a time"
+}
+
+func (m *lessonMode) lesson() int {
+	if m.index >= 0 {
+		return m.index
+	}
+	return currentLessonIndex()
+}
+
+// NOTE: drills are generated from the unlocked keys, transforms would add keys the player hasn't learned yet
+func (m *lessonMode) Pipeline(settings UserSettings) []string {
+	return modePipeline(settings, GameModeLessons, []string{"collapse_whitespace"})
+}
+
+func (m *lessonMode) GenerateText(settings UserSettings) string {
+	layout, err := LoadKeyboardLayout(settings.KeyboardLayout)
+	if err != nil {
+		devlog.Log("Lessons: Could not load keyboard layout %s: %v", settings.KeyboardLayout, err)
+	}
+
+	pack, err := LoadLanguagePack(settings.Language)
+	if err != nil {
+		devlog.Log("Lessons: Could not load language pack %s: %v", settings.Language, err)
+	}
+
+	return TextPipeline(settings).Apply(GenerateLessonDrill(m.lesson(), layout, pack))
+}
+
+func (m *lessonMode) IsComplete(state GameState) bool  { return finishedText(state) }
+func (m *lessonMode) Score(state GameState) GameResult { return scoreText(state) }
+
+func (m *lessonMode) HUD(state GameState) string {
+	index := m.lesson()
+	lesson := Lessons[index]
+	return elapsedHUD(state) + " " + HelpStyle(fmt.Sprintf("Lesson %d: %s (pass at %.0f WPM, %.0f%% accuracy)", index+1, lesson.Title, lesson.MinWPM, lesson.MinAccuracy))
+}
+
+func (m *lessonMode) Finish(result GameResult, end *EndGameModel) {
+	end.lesson = m.lesson()
+	end.lessonPassed = recordLessonResult(end.lesson, result.WPM, result.Accuracy)
+}
+
+// codeMode types snippets of source code. The engine works word by word, so
+// indentation and line breaks are collapsed into single spaces.
+type codeMode struct{}
+
+func (m *codeMode) Name() string        { return GameModeCode }
+func (m *codeMode) Title() string       { return "Code" }
+func (m *codeMode) Description() string { return "Source code snippets with brackets and symbols" }
+
+// NOTE: symbols matter in code, only control characters and whitespace are cleaned up
+func (m *codeMode) Pipeline(settings UserSettings) []string {
+	return modePipeline(settings, GameModeCode, []string{"strip_control", "collapse_whitespace"})
+}
+
+func (m *codeMode) GenerateText(settings UserSettings) string {
+	return TextPipeline(settings).Apply(codeSnippets[utils.RandomIntn(len(codeSnippets))])
+}
+
+func (m *codeMode) IsComplete(state GameState) bool  { return finishedText(state) }
+func (m *codeMode) Score(state GameState) GameResult { return scoreText(state) }
+func (m *codeMode) HUD(state GameState) string       { return elapsedHUD(state) }
+
+var codeSnippets = []string{
+	`func max(a, b int) int { if a > b { return a }; return b }`,
+	`for i := 0; i < len(items); i++ { total += items[i].Price * items[i].Count }`,
+	`if err != nil { return nil, fmt.Errorf("open %s: %w", path, err) }`,
+	`def fib(n): return n if n < 2 else fib(n - 1) + fib(n - 2)`,
+	`const sum = values.filter((v) => v > 0).reduce((a, b) => a + b, 0);`,
+	`SELECT name, COUNT(*) FROM users WHERE active = 1 GROUP BY name;`,
+	`fn main() { let v: Vec<i32> = (1..=10).map(|x| x * x).collect(); println!("{:?}", v); }`,
+	`grep -rn "TODO" src/ | awk -F: '{ print $1 }' | sort | uniq -c`,
+	`#include <stdio.h> int main(void) { printf("hello, world\n"); return 0; }`,
+	`public static int[] twoSum(int[] nums, int target) { return new int[] { 0, 1 }; }`,
+}
+
+// renderModeHUD joins the title and the mode's HUD for the game header.
+func renderModeHUD(mode GameMode, state GameState) string {
+	hud := mode.HUD(state)
+	if hud == "" {
+		return "GoTyper - Typing Practice"
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, "GoTyper - Typing Practice ", hud)
+}
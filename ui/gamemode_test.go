@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimedWords(t *testing.T) {
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+
+	tests := []struct {
+		limit    int
+		minWords int // enough for 200 WPM
+	}{
+		{15, 50},
+		{60, 200},
+		{120, 400},
+	}
+
+	for _, tt := range tests {
+		CurrentSettings = DefaultSettings
+		CurrentSettings.GameMode = GameModeTimed
+		CurrentSettings.UseNumbers = false
+		CurrentSettings.UsePunctuation = false
+		CurrentSettings.TimeLimit = tt.limit
+
+		text := LookupGameMode(GameModeTimed).GenerateText(CurrentSettings)
+		if words := len(strings.Fields(text)); words < tt.minWords {
+			t.Errorf("%ds game: %d words, want at least %d", tt.limit, words, tt.minWords)
+		}
+	}
+}
+
+func TestLessonGameKeepsItsLesson(t *testing.T) {
+	testConfigDir(t)
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+	CurrentSettings = DefaultSettings
+	CurrentSettings.GameMode = GameModeLessons
+
+	model := NewTypingModel(0, 0, "asdf jkl;")
+	mode, ok := model.mode.(*lessonMode)
+	if !ok || mode.index != 0 {
+		t.Fatalf("the game plays %+v, want the first lesson", model.mode)
+	}
+
+	// passing the lesson elsewhere moves the registered mode on, not the game
+	if !recordLessonResult(0, 200, 100) {
+		t.Fatal("could not pass the first lesson")
+	}
+	if current := currentLessonIndex(); current != 1 {
+		t.Fatalf("current lesson %d, want 1", current)
+	}
+	if hud := model.mode.HUD(model.state()); !strings.Contains(hud, "Lesson 1:") {
+		t.Errorf("HUD %q switched lessons mid-game", hud)
+	}
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type Finger int
+
+const (
+	Pinky Finger = iota
+	Ring
+	Middle
+	Index
+	Thumb
+)
+
+const (
+	keyboardFlashDuration = 300 * time.Millisecond // How long a mistyped key stays highlighted
+	keyboardMinWidth      = 64                     // Narrowest terminal the keyboard is drawn in
+)
+
+// FingerColors are the key backgrounds for each finger, the same on both hands.
+var FingerColors = map[Finger]string{
+	Pinky:  "#5B3A6E", // purple
+	Ring:   "#2F5A7A", // blue
+	Middle: "#2F6B4F", // green
+	Index:  "#7A5A2F", // amber
+	Thumb:  "#4A4A4A", // grey
+}
+
+// NOTE: standard touch typing assignment by physical QWERTY position, the
+// emulated layout only changes the labels
+var rowFingers = [][]Finger{
+	{Pinky, Pinky, Ring, Middle, Index, Index, Index, Index, Middle, Ring, Pinky, Pinky, Pinky},
+	{Pinky, Ring, Middle, Index, Index, Index, Index, Middle, Ring, Pinky, Pinky, Pinky, Pinky},
+	{Pinky, Ring, Middle, Index, Index, Index, Index, Middle, Ring, Pinky, Pinky},
+	{Pinky, Ring, Middle, Index, Index, Index, Index, Middle, Ring, Pinky},
+}
+
+// leftHandKeys is the number of keys per row pressed by the left hand.
+var leftHandKeys = []int{6, 5, 5, 5}
+
+// rowIndent staggers the rows like a physical keyboard, the bottom row
+// starts with a shift key so it is indented less.
+var rowIndent = []int{0, 2, 3, 1}
+
+type keyPos struct {
+	row, col int
+	shifted  bool
+}
+
+// findKey looks up which physical key produces g on the layout.
+func findKey(layout *KeyboardLayout, g string) (keyPos, bool) {
+	if layout == nil {
+		layout = &qwertyLayout
+	}
+
+	for shifted, rows := range [][]string{layout.Rows, layout.ShiftedRows} {
+		for row, keys := range rows {
+			for col, key := range []rune(keys) {
+				if string(key) == g {
+					return keyPos{row: row, col: col, shifted: shifted == 1}, true
+				}
+			}
+		}
+	}
+
+	return keyPos{}, false
+}
+
+func isLeftHand(pos keyPos) bool {
+	return pos.row < len(leftHandKeys) && pos.col < leftHandKeys[pos.row]
+}
+
+func fingerFor(row, col int) Finger {
+	if row < len(rowFingers) && col < len(rowFingers[row]) {
+		return rowFingers[row][col]
+	}
+	return Pinky
+}
+
+// RenderKeyboard draws the layout with the key for next highlighted and the
+// key for flash (a mistyped key) shown in the error color.
+func RenderKeyboard(layout *KeyboardLayout, next, flash string) string {
+	if layout == nil {
+		layout = &qwertyLayout
+	}
+
+	nextPos, hasNext := findKey(layout, next)
+	flashPos, hasFlash := findKey(layout, flash)
+
+	keyStyle := func(bg string) lipgloss.Style {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#EEEEEE")).
+			Background(lipgloss.Color(bg)).
+			Padding(0, 1)
+	}
+	nextStyle := lipgloss.NewStyle().
+		Foreground(GetColor("cursor_fg")).
+		Background(GetColor("cursor_bg")).
+		Bold(true).
+		Padding(0, 1)
+	flashStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(GetColor("text_error")).
+		Bold(true).
+		Padding(0, 1)
+
+	renderKey := func(label string, pos keyPos, finger Finger) string {
+		switch {
+		case hasFlash && flash != next && pos.row == flashPos.row && pos.col == flashPos.col:
+			return flashStyle.Render(label)
+		case hasNext && pos.row == nextPos.row && pos.col == nextPos.col:
+			return nextStyle.Render(label)
+		}
+		return keyStyle(FingerColors[finger]).Render(label)
+	}
+
+	var lines []string
+	for row, keys := range layout.Rows {
+		var line strings.Builder
+		line.WriteString(strings.Repeat(" ", rowIndent[min(row, len(rowIndent)-1)]))
+
+		if row == len(layout.Rows)-1 {
+			line.WriteString(renderShift(hasNext && nextPos.shifted && !isLeftHand(nextPos), keyStyle, nextStyle))
+		}
+
+		for col, key := range []rune(keys) {
+			line.WriteString(renderKey(string(key), keyPos{row: row, col: col}, fingerFor(row, col)))
+		}
+
+		if row == len(layout.Rows)-1 {
+			line.WriteString(renderShift(hasNext && nextPos.shifted && isLeftHand(nextPos), keyStyle, nextStyle))
+		}
+
+		lines = append(lines, line.String())
+	}
+
+	space := keyStyle(FingerColors[Thumb])
+	if next == " " {
+		space = nextStyle
+	} else if flash == " " && next != " " {
+		space = flashStyle
+	}
+	lines = append(lines, strings.Repeat(" ", 12)+space.Render(strings.Repeat(" ", 21)))
+
+	legend := make([]string, 0, 4)
+	for _, f := range []struct {
+		finger Finger
+		name   string
+	}{{Pinky, "pinky"}, {Ring, "ring"}, {Middle, "middle"}, {Index, "index"}} {
+		legend = append(legend, keyStyle(FingerColors[f.finger]).Render(f.name))
+	}
+	lines = append(lines, "", strings.Join(legend, " "))
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderShift draws a shift key, highlighted when the next key needs the
+// shift on the opposite hand.
+func renderShift(active bool, keyStyle func(string) lipgloss.Style, nextStyle lipgloss.Style) string {
+	if active {
+		return nextStyle.Render("⇧")
+	}
+	return keyStyle(FingerColors[Pinky]).Render("⇧")
+}
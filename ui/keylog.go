@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// KeyAction is what a keystroke did to the text.
+type KeyAction string
+
+const (
+	ActionType       KeyAction = "type"        // letters or a space were typed
+	ActionBackspace  KeyAction = "backspace"   // one letter was deleted
+	ActionDeleteWord KeyAction = "delete_word" // a word delete shortcut was used
+)
+
+// Keystroke is one entry of a game's keystroke log.
+type Keystroke struct {
+	At     time.Duration `json:"at"` // since the first key of the game
+	Action KeyAction     `json:"action"`
+	Text   string        `json:"text,omitempty"` // what was typed, after layout remapping
+}
+
+// DefaultWordDeleteKeys are the shortcuts that delete a whole word. Most
+// terminals send Ctrl+Backspace as ctrl+h, which CtrlHDeletesWord turns on.
+var DefaultWordDeleteKeys = []string{"alt+backspace", "ctrl+w"}
+
+// isWordDeleteKey reports whether key is one of the user's word delete shortcuts.
+func isWordDeleteKey(key string) bool {
+	// NOTE: some terminals send ctrl+h for a plain Backspace, so it's opt-in
+	if key == "ctrl+h" && CurrentSettings.CtrlHDeletesWord {
+		return true
+	}
+	keys := CurrentSettings.WordDeleteKeys
+	if keys == nil {
+		keys = DefaultWordDeleteKeys
+	}
+	return slices.ContainsFunc(keys, func(shortcut string) bool {
+		return strings.ToLower(strings.TrimSpace(shortcut)) == key
+	})
+}
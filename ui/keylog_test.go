@@ -0,0 +1,38 @@
+package ui
+
+import "testing"
+
+func TestIsWordDeleteKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		ctrlH bool
+		key   string
+		want  bool
+	}{
+		{"default alt+backspace", nil, false, "alt+backspace", true},
+		{"default ctrl+w", nil, false, "ctrl+w", true},
+		{"ctrl+h is a backspace by default", nil, false, "ctrl+h", false},
+		{"ctrl+h opted in", nil, true, "ctrl+h", true},
+		{"ctrl+h opted in with custom keys", []string{"ctrl+u"}, true, "ctrl+h", true},
+		{"ctrl+backspace isn't a default", nil, false, "ctrl+backspace", false},
+		{"plain backspace", nil, true, "backspace", false},
+		{"custom keys", []string{" Ctrl+U "}, false, "ctrl+u", true},
+		{"custom keys replace the defaults", []string{"ctrl+u"}, false, "ctrl+w", false},
+		{"no keys at all", []string{}, true, "ctrl+w", false},
+	}
+
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CurrentSettings.WordDeleteKeys = tt.keys
+			CurrentSettings.CtrlHDeletesWord = tt.ctrlH
+
+			if got := isWordDeleteKey(tt.key); got != tt.want {
+				t.Errorf("isWordDeleteKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/prime-run/go-typer/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultLanguage = "en" // Language used when none is configured
+
+	languagesDirName = "languages" // Directory (embedded and in the config dir) holding language packs
+)
+
+//go:embed languages/*.yml
+var embeddedLanguages embed.FS
+
+// LanguagePack is a word frequency list plus a set of quotes for one language.
+type LanguagePack struct {
+	Code      string        `yaml:"code"`      // ISO 639-1 code, also the file name (de.yml)
+	Name      string        `yaml:"name"`      // Name of the language in that language
+	Direction TextDirection `yaml:"direction"` // ltr or rtl, detected from the text when empty
+	Words     []string      `yaml:"words"`     // Common words, most frequent first
+	Quotes    []Quote       `yaml:"quotes"`    // Passages used for quote based modes
+}
+
+// Quote is a single passage from a language pack.
+type Quote struct {
+	Text   string `yaml:"text"`
+	Author string `yaml:"author,omitempty"`
+}
+
+// LoadLanguagePack loads a language pack by code. Packs in the user config
+// directory take precedence over the embedded ones so they can be overridden,
+// except in seeded games, where the embedded pack wins so the same seed gives
+// the same text on every machine.
+func LoadLanguagePack(code string) (*LanguagePack, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		code = DefaultLanguage
+	}
+
+	if !isValidLanguageCode(code) {
+		return nil, fmt.Errorf("invalid language code: %s", code)
+	}
+
+	fileName := code + YMLSuffix
+
+	data, err := os.ReadFile(filepath.Join(GetLanguagesDirPath(), fileName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading language pack: %w", err)
+	}
+
+	if err != nil || utils.RandomSeeded() {
+		if embedded, embeddedErr := embeddedLanguages.ReadFile(languagesDirName + "/" + fileName); embeddedErr == nil {
+			data, err = embedded, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("language pack not found: %s", code)
+	}
+
+	var pack LanguagePack
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("error parsing language pack %s: %w", code, err)
+	}
+
+	if pack.Code == "" {
+		pack.Code = code
+	}
+	if pack.Name == "" {
+		pack.Name = code
+	}
+
+	if pack.Direction != DirectionAuto && pack.Direction != DirectionLTR && pack.Direction != DirectionRTL {
+		return nil, fmt.Errorf("language pack %s has invalid direction %q (expected ltr or rtl)", code, pack.Direction)
+	}
+
+	if len(pack.Words) == 0 && len(pack.Quotes) == 0 {
+		return nil, fmt.Errorf("language pack %s has no words or quotes", code)
+	}
+
+	return &pack, nil
+}
+
+// languageName is the display name of a language pack, or its code when the
+// pack can't be loaded.
+func languageName(code string) string {
+	pack, err := LoadLanguagePack(code)
+	if err != nil {
+		return code
+	}
+	return pack.Name
+}
+
+func isValidLanguageCode(code string) bool {
+	for _, c := range code {
+		if !(unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// GetLanguagesDirPath returns the directory users can drop extra packs into,
+// next to colorschemes/ in the config directory.
+func GetLanguagesDirPath() string {
+	return filepath.Join(utils.GetConfigDirPath(), languagesDirName)
+}
+
+// ListAvailableLanguages returns the codes of the embedded packs followed by
+// any additional packs found in the config directory.
+func ListAvailableLanguages() []string {
+	var languages []string
+
+	entries, err := embeddedLanguages.ReadDir(languagesDirName)
+	if err == nil {
+		for _, entry := range entries {
+			languages = append(languages, strings.TrimSuffix(entry.Name(), YMLSuffix))
+		}
+	}
+
+	files, err := os.ReadDir(GetLanguagesDirPath())
+	if err == nil {
+		for _, file := range files {
+			if !file.IsDir() && strings.HasSuffix(file.Name(), YMLSuffix) {
+				code := strings.TrimSuffix(file.Name(), YMLSuffix)
+				if !slices.Contains(languages, code) {
+					languages = append(languages, code)
+				}
+			}
+		}
+	}
+
+	return languages
+}
+
+// RandomQuote returns a random quote formatted like the online sources
+// ("text - author"). Packs without quotes fall back to random words.
+func (p *LanguagePack) RandomQuote() string {
+	if len(p.Quotes) == 0 {
+		return strings.Join(p.RandomWords(30), " ")
+	}
+
+	quote := p.Quotes[utils.RandomIntn(len(p.Quotes))]
+	text := strings.TrimSpace(quote.Text)
+	if quote.Author == "" {
+		return text
+	}
+
+	return fmt.Sprintf("%s - %s", text, quote.Author)
+}
+
+// RandomWords samples n words from the frequency list.
+func (p *LanguagePack) RandomWords(n int) []string {
+	if len(p.Words) == 0 {
+		return nil
+	}
+
+	words := make([]string, n)
+	for i := range words {
+		words[i] = p.Words[utils.RandomIntn(len(p.Words))]
+	}
+
+	return words
+}
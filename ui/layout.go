@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prime-run/go-typer/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LayoutQwerty  = "qwerty"  // No remapping, the physical layout
+	LayoutDvorak  = "dvorak"  // Dvorak simplified keyboard
+	LayoutColemak = "colemak" // Colemak
+	LayoutWorkman = "workman" // Workman
+
+	layoutsDirName = "layouts" // Directory in the config dir holding layout files
+)
+
+// KeyboardLayout describes an alternative layout by the characters its keys
+// produce, row by row, in the physical positions of a QWERTY keyboard.
+type KeyboardLayout struct {
+	Name        string   `yaml:"name"`         // Display name
+	Rows        []string `yaml:"rows"`         // Number, top, home and bottom row
+	ShiftedRows []string `yaml:"shifted_rows"` // Same rows with shift held
+
+	remap map[rune]rune
+}
+
+var (
+	// qwertyLayout is the physical reference every layout file is mapped against.
+	qwertyLayout = KeyboardLayout{
+		Name:        "QWERTY",
+		Rows:        []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"},
+		ShiftedRows: []string{"~!@#$%^&*()_+", "QWERTYUIOP{}|", "ASDFGHJKL:\"", "ZXCVBNM<>?"},
+	}
+
+	defaultLayouts = map[string]KeyboardLayout{
+		LayoutQwerty: qwertyLayout,
+		LayoutDvorak: {
+			Name:        "Dvorak",
+			Rows:        []string{"`1234567890[]", "',.pyfgcrl/=\\", "aoeuidhtns-", ";qjkxbmwvz"},
+			ShiftedRows: []string{"~!@#$%^&*(){}", "\"<>PYFGCRL?+|", "AOEUIDHTNS_", ":QJKXBMWVZ"},
+		},
+		LayoutColemak: {
+			Name:        "Colemak",
+			Rows:        []string{"`1234567890-=", "qwfpgjluy;[]\\", "arstdhneio'", "zxcvbkm,./"},
+			ShiftedRows: []string{"~!@#$%^&*()_+", "QWFPGJLUY:{}|", "ARSTDHNEIO\"", "ZXCVBKM<>?"},
+		},
+		LayoutWorkman: {
+			Name:        "Workman",
+			Rows:        []string{"`1234567890-=", "qdrwbjfup;[]\\", "ashtgyneoi'", "zxmcvkl,./"},
+			ShiftedRows: []string{"~!@#$%^&*()_+", "QDRWBJFUP:{}|", "ASHTGYNEOI\"", "ZXMCVKL<>?"},
+		},
+	}
+
+	// layoutCache holds the layouts parsed so far by name, the files are only
+	// read once per run.
+	layoutCache   = map[string]*KeyboardLayout{}
+	layoutCacheMu sync.Mutex
+)
+
+// InitLayouts writes the built-in layouts to the config directory so they
+// can be copied and edited.
+func InitLayouts() {
+	ensureDefaultLayoutsExist()
+}
+
+// GetLayoutsDirPath returns the directory holding keyboard layout files.
+func GetLayoutsDirPath() string {
+	return filepath.Join(utils.GetConfigDirPath(), layoutsDirName)
+}
+
+// LoadKeyboardLayout loads a layout file from the config directory and
+// builds the QWERTY to layout key map. Layouts are cached after the first
+// load, the returned layout is shared and must not be changed.
+func LoadKeyboardLayout(name string) (*KeyboardLayout, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = LayoutQwerty
+	}
+
+	if !utils.IsValidThemeName(name) || strings.HasSuffix(name, YMLSuffix) {
+		return nil, fmt.Errorf("invalid layout name: %s", name)
+	}
+
+	layoutCacheMu.Lock()
+	defer layoutCacheMu.Unlock()
+
+	if layout, ok := layoutCache[name]; ok {
+		return layout, nil
+	}
+
+	layout, err := readKeyboardLayout(name)
+	if err != nil {
+		return nil, err
+	}
+	layoutCache[name] = layout
+	return layout, nil
+}
+
+func readKeyboardLayout(name string) (*KeyboardLayout, error) {
+	data, err := os.ReadFile(filepath.Join(GetLayoutsDirPath(), name+YMLSuffix))
+	if err != nil {
+		builtin, ok := defaultLayouts[name]
+		if !ok {
+			return nil, fmt.Errorf("error reading layout file: %w", err)
+		}
+		layout := builtin
+		if err := layout.buildRemap(); err != nil {
+			return nil, err
+		}
+		return &layout, nil
+	}
+
+	var layout KeyboardLayout
+	if err := yaml.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("error parsing layout file: %w", err)
+	}
+
+	if layout.Name == "" {
+		layout.Name = utils.GetDisplayThemeName(name)
+	}
+
+	if err := layout.buildRemap(); err != nil {
+		return nil, fmt.Errorf("layout %s: %w", name, err)
+	}
+
+	return &layout, nil
+}
+
+func (l *KeyboardLayout) buildRemap() error {
+	l.remap = make(map[rune]rune)
+
+	if err := l.addRows(qwertyLayout.Rows, l.Rows); err != nil {
+		return err
+	}
+	if len(l.ShiftedRows) > 0 {
+		if err := l.addRows(qwertyLayout.ShiftedRows, l.ShiftedRows); err != nil {
+			return fmt.Errorf("shifted %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (l *KeyboardLayout) addRows(physical, rows []string) error {
+	if len(rows) != len(physical) {
+		return fmt.Errorf("rows: expected %d rows, got %d", len(physical), len(rows))
+	}
+
+	for i, row := range rows {
+		keys := []rune(row)
+		physicalKeys := []rune(physical[i])
+		if len(keys) != len(physicalKeys) {
+			return fmt.Errorf("rows: row %d should have %d keys, got %d", i+1, len(physicalKeys), len(keys))
+		}
+		for j, key := range keys {
+			if key != physicalKeys[j] {
+				l.remap[physicalKeys[j]] = key
+			}
+		}
+	}
+
+	return nil
+}
+
+// Remap translates runes typed on a QWERTY keyboard into what the emulated
+// layout would have produced. Runes outside the layout pass through.
+func (l *KeyboardLayout) Remap(runes []rune) []rune {
+	if l == nil || len(l.remap) == 0 {
+		return runes
+	}
+
+	remapped := make([]rune, len(runes))
+	for i, r := range runes {
+		if mapped, ok := l.remap[r]; ok {
+			remapped[i] = mapped
+		} else {
+			remapped[i] = r
+		}
+	}
+
+	return remapped
+}
+
+// IsEmulated reports whether the layout changes any key.
+func (l *KeyboardLayout) IsEmulated() bool {
+	return l != nil && len(l.remap) > 0
+}
+
+// ListAvailableLayouts returns the built-in layouts followed by any custom
+// layout files in the config directory.
+func ListAvailableLayouts() []string {
+	layouts := []string{LayoutQwerty, LayoutDvorak, LayoutColemak, LayoutWorkman}
+
+	files, err := os.ReadDir(GetLayoutsDirPath())
+	if err == nil {
+		for _, file := range files {
+			if !file.IsDir() && strings.HasSuffix(file.Name(), YMLSuffix) {
+				name := strings.TrimSuffix(file.Name(), YMLSuffix)
+				if !slices.Contains(layouts, name) {
+					layouts = append(layouts, name)
+				}
+			}
+		}
+	}
+
+	return layouts
+}
+
+func ensureDefaultLayoutsExist() {
+	layoutsDir := GetLayoutsDirPath()
+	if err := os.MkdirAll(layoutsDir, 0755); err != nil {
+		return
+	}
+
+	for name, layout := range defaultLayouts {
+		layoutPath := filepath.Join(layoutsDir, name+YMLSuffix)
+
+		if _, err := os.Stat(layoutPath); err == nil {
+			continue
+		}
+
+		yamlData, err := yaml.Marshal(layout)
+		if err != nil {
+			continue
+		}
+
+		os.WriteFile(layoutPath, yamlData, 0644)
+	}
+}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/prime-run/go-typer/leaderboard"
+	devlog "github.com/prime-run/go-typer/log"
+)
+
+const leaderboardLimit = 15
+
+// leaderboardSubmittedMsg reports the result of a submission.
+type leaderboardSubmittedMsg struct {
+	entry leaderboard.Entry
+	err   error
+}
+
+// leaderboardFetchedMsg carries a leaderboard fetched from the server.
+type leaderboardFetchedMsg struct {
+	entries []leaderboard.Entry
+	err     error
+}
+
+// leaderboardUser is the name results are submitted under.
+func leaderboardUser() string {
+	if CurrentSettings.LeaderboardUser != "" {
+		return CurrentSettings.LeaderboardUser
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "anonymous"
+}
+
+// resultLength describes how long a game of mode was, so only comparable
+// results share a leaderboard.
+func resultLength(mode string) string {
+	switch mode {
+	case GameModeTimed:
+		return fmt.Sprintf("%ds", CurrentSettings.TimeLimit)
+	case GameModeWords:
+		return fmt.Sprintf("%d words", CurrentSettings.WordCount)
+	}
+	return CurrentSettings.TextLength
+}
+
+// submit sends the game's result to the leaderboard server.
+func (m *EndGameModel) submit() tea.Cmd {
+	entry := m.entry()
+
+	m.submitStatus = HintStyle("Submitting...")
+	client := leaderboard.NewClient(CurrentSettings.LeaderboardURL)
+	return func() tea.Msg {
+		stored, err := client.Submit(entry)
+		return leaderboardSubmittedMsg{entry: stored, err: err}
+	}
+}
+
+// entry is the game's result as submitted to the leaderboard, with what it
+// takes to replay it.
+func (m *EndGameModel) entry() leaderboard.Entry {
+	length := resultLength(m.log.Mode)
+	if m.log.TimeLimit > 0 {
+		length = fmt.Sprintf("%ds", int(m.log.TimeLimit.Seconds()))
+	}
+
+	entry := leaderboard.Entry{
+		User:            leaderboardUser(),
+		Mode:            m.log.Mode,
+		Length:          length,
+		WPM:             m.log.WPM,
+		Accuracy:        m.log.Accuracy,
+		PassageHash:     leaderboard.PassageHash(m.log.Text),
+		Text:            m.log.Text,
+		Elapsed:         m.log.Elapsed,
+		TimeLimit:       m.log.TimeLimit,
+		ErrorPolicy:     m.log.ErrorPolicy,
+		BackspacePolicy: m.log.BackspacePolicy,
+	}
+	if keystrokes, err := json.Marshal(m.log.Keystrokes); err == nil {
+		entry.Keystrokes = keystrokes
+	}
+	return entry
+}
+
+// VerifyEntry replays a leaderboard entry's keystroke log, see Verify.
+func VerifyEntry(entry leaderboard.Entry) Verification {
+	result := ResultLog{
+		Text:            entry.Text,
+		Mode:            entry.Mode,
+		WPM:             entry.WPM,
+		Accuracy:        entry.Accuracy,
+		Elapsed:         entry.Elapsed,
+		TimeLimit:       entry.TimeLimit,
+		ErrorPolicy:     entry.ErrorPolicy,
+		BackspacePolicy: entry.BackspacePolicy,
+	}
+	if len(entry.Keystrokes) > 0 {
+		if err := json.Unmarshal(entry.Keystrokes, &result.Keystrokes); err != nil {
+			return Verification{Issues: []string{"the keystroke log is unreadable: " + err.Error()}}
+		}
+	}
+	if leaderboard.PassageHash(entry.Text) != entry.PassageHash {
+		v := Verify(result)
+		v.Issues = append([]string{"the passage doesn't match its hash"}, v.Issues...)
+		return v
+	}
+	return Verify(result)
+}
+
+// LeaderboardModel shows the top results of a leaderboard server, one game
+// mode at a time.
+type LeaderboardModel struct {
+	client   *leaderboard.Client
+	modes    []string // "" shows every mode
+	mode     int
+	entries  []leaderboard.Entry
+	loading  bool
+	err      error
+	back     tea.Model // model esc returns to, nil to quit
+	width    int
+	height   int
+	lastTick time.Time
+}
+
+func NewLeaderboardModel(width, height int, url, mode string) *LeaderboardModel {
+	m := &LeaderboardModel{
+		client:   leaderboard.NewClient(url),
+		modes:    append([]string{""}, GameModeNames()...),
+		width:    width,
+		height:   height,
+		lastTick: time.Now(),
+	}
+	for i, name := range m.modes {
+		if name == mode {
+			m.mode = i
+		}
+	}
+	return m
+}
+
+func (m *LeaderboardModel) Init() tea.Cmd {
+	return tea.Batch(InitGlobalTick(), m.fetch())
+}
+
+func (m *LeaderboardModel) fetch() tea.Cmd {
+	m.loading = true
+	query := leaderboard.Query{Mode: m.modes[m.mode], Limit: leaderboardLimit}
+	if query.Mode != "" {
+		query.Length = resultLength(query.Mode)
+	}
+	client := m.client
+	return func() tea.Msg {
+		entries, err := client.Top(query)
+		return leaderboardFetchedMsg{entries: entries, err: err}
+	}
+}
+
+func (m *LeaderboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case GlobalTickMsg:
+		var cmd tea.Cmd
+		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
+		return m, cmd
+
+	case leaderboardFetchedMsg:
+		m.loading = false
+		m.entries, m.err = msg.entries, msg.err
+		if msg.err != nil {
+			devlog.Log("Leaderboard: %v", msg.err)
+		}
+		return m, nil
+
+	case leaderboardSubmittedMsg:
+		// NOTE: the submission finished while the leaderboard was open
+		if m.back != nil {
+			m.back.Update(msg)
+		}
+		return m, m.fetch()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			if m.back != nil {
+				return m.back, InitGlobalTick()
+			}
+			return m, tea.Quit
+		case "left", "h":
+			m.mode = (m.mode + len(m.modes) - 1) % len(m.modes)
+			return m, m.fetch()
+		case "right", "l", "tab":
+			m.mode = (m.mode + 1) % len(m.modes)
+			return m, m.fetch()
+		case "r":
+			return m, m.fetch()
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.back != nil {
+			m.back.Update(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *LeaderboardModel) View() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(GetColor("timer")).
+		Render("Leaderboard")
+
+	var tabs []string
+	for i, mode := range m.modes {
+		name := "All"
+		if mode != "" {
+			name = LookupGameMode(mode).Title()
+		}
+		if i == m.mode {
+			tabs = append(tabs, EndGameSelectedOptionStyle.Render(name))
+		} else {
+			tabs = append(tabs, EndGameOptionStyle.Render(name))
+		}
+	}
+
+	var body string
+	switch {
+	case m.err != nil:
+		body = ErrorStyle.Render(m.err.Error())
+	case m.loading && m.entries == nil:
+		body = HintStyle("Loading...")
+	case len(m.entries) == 0:
+		body = HintStyle("No results yet, be the first!")
+	default:
+		body = m.renderEntries()
+	}
+
+	subtitle := HelpStyle(m.client.BaseURL)
+	if mode := m.modes[m.mode]; mode != "" {
+		subtitle = HelpStyle(fmt.Sprintf("%s • %s", resultLength(mode), m.client.BaseURL))
+	}
+
+	content := title + "\n" + subtitle + "\n\n" +
+		strings.Join(tabs, "  ") + "\n\n" +
+		body + "\n\n" +
+		HelpStyle("←/→ switch mode • r refresh • esc back")
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+func (m *LeaderboardModel) renderEntries() string {
+	userWidth := len("User")
+	for _, entry := range m.entries {
+		userWidth = max(userWidth, lipgloss.Width(entry.User))
+	}
+
+	header := fmt.Sprintf("%3s  %-*s  %7s  %8s  %-8s  %-10s  %s", "#", userWidth, "User", "WPM", "Accuracy", "Mode", "Length", "Date")
+	lines := []string{HelpStyle(header)}
+
+	user := leaderboardUser()
+	for i, entry := range m.entries {
+		line := fmt.Sprintf("%3d  %-*s  %7.1f  %7.1f%%  %-8s  %-10s  %s",
+			i+1, userWidth, entry.User, entry.WPM, entry.Accuracy, entry.Mode, entry.Length,
+			entry.Submitted.Local().Format("2006-01-02"))
+
+		style := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+		if entry.User == user {
+			style = style.Foreground(GetColor("text_correct")).Bold(true)
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RunLeaderboard shows the leaderboard of the server at url.
+func RunLeaderboard(url, mode string) error {
+	if _, err := tea.NewProgram(NewLeaderboardModel(0, 0, url, mode), tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error showing leaderboard: %w", err)
+	}
+	return nil
+}
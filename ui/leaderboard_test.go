@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prime-run/go-typer/leaderboard"
+)
+
+func TestSubmitReplay(t *testing.T) {
+	const passage = "the quick brown fox"
+	honest := func() ResultLog {
+		return gameLog(GameModeNormal, passage, "the quikc brpwn\b\b\bown fox", 200*time.Millisecond, 0, 0, ErrorPolicyFree)
+	}
+
+	tests := []struct {
+		name       string
+		log        ResultLog
+		wantListed bool
+	}{
+		{"honest game", honest(), true},
+		{"inflated wpm", func() ResultLog { log := honest(); log.WPM *= 2; return log }(), false},
+		{"inflated accuracy", func() ResultLog { log := honest(); log.Accuracy = 100; return log }(), false},
+		{"keystrokes of another game", func() ResultLog {
+			log := honest()
+			log.Keystrokes = gameLog(GameModeNormal, passage, "the quick brown fox", 200*time.Millisecond, 0, 0, ErrorPolicyFree).Keystrokes
+			return log
+		}(), false},
+		{"another passage", func() ResultLog { log := honest(); log.Text = "the lazy dog"; return log }(), false},
+	}
+
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+	CurrentSettings = DefaultSettings
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := leaderboard.OpenStore(filepath.Join(t.TempDir(), "leaderboard.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			check := func(entry leaderboard.Entry) []string { return VerifyEntry(entry).Issues }
+			server := httptest.NewServer(leaderboard.Handler(store, check, nil))
+			defer server.Close()
+
+			end := NewEndGameModel(tt.log.WPM, tt.log.Accuracy, 0, 0, 0, tt.log.Text)
+			end.log = &tt.log
+			client := leaderboard.NewClient(server.URL)
+			stored, err := client.Submit(end.entry())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if flagged := len(stored.Flags) > 0; flagged == tt.wantListed {
+				t.Errorf("flags %q, want flagged = %v", stored.Flags, !tt.wantListed)
+			}
+
+			top, err := client.Top(leaderboard.Query{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if listed := len(top) == 1; listed != tt.wantListed {
+				t.Errorf("on the leaderboard = %v, want %v", listed, tt.wantListed)
+			}
+
+			end.Update(leaderboardSubmittedMsg{entry: stored})
+			if keptOff := strings.Contains(end.submitStatus, "Kept off the leaderboard"); keptOff == tt.wantListed {
+				t.Errorf("end screen says %q", end.submitStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/utils"
+)
+
+const (
+	lessonDrillWords = 30 // Words in a generated drill
+)
+
+// Lesson introduces a few keys and sets the bar to unlock the next one.
+// Keys are physical QWERTY positions, so the curriculum follows the
+// emulated keyboard layout.
+type Lesson struct {
+	ID          string
+	Title       string
+	Keys        string  // keys introduced in this lesson, empty for reviews
+	MinWPM      float64 // minimum WPM to pass
+	MinAccuracy float64 // minimum accuracy (percent) to pass
+}
+
+// Lessons is the touch typing curriculum, home row first.
+var Lessons = []Lesson{
+	{ID: "home-fj", Title: "Home row: index fingers", Keys: "fj", MinWPM: 8, MinAccuracy: 90},
+	{ID: "home-dk", Title: "Home row: middle fingers", Keys: "dk", MinWPM: 8, MinAccuracy: 90},
+	{ID: "home-sl", Title: "Home row: ring fingers", Keys: "sl", MinWPM: 10, MinAccuracy: 90},
+	{ID: "home-a", Title: "Home row: pinkies", Keys: "a;", MinWPM: 10, MinAccuracy: 90},
+	{ID: "home-gh", Title: "Home row: index reach", Keys: "gh", MinWPM: 10, MinAccuracy: 90},
+	{ID: "home-review", Title: "Home row review", MinWPM: 15, MinAccuracy: 92},
+	{ID: "top-ru", Title: "Top row: index fingers", Keys: "ru", MinWPM: 12, MinAccuracy: 90},
+	{ID: "top-ei", Title: "Top row: middle fingers", Keys: "ei", MinWPM: 12, MinAccuracy: 90},
+	{ID: "top-wo", Title: "Top row: ring fingers", Keys: "wo", MinWPM: 12, MinAccuracy: 90},
+	{ID: "top-qp", Title: "Top row: pinkies", Keys: "qp", MinWPM: 12, MinAccuracy: 90},
+	{ID: "top-ty", Title: "Top row: index reach", Keys: "ty", MinWPM: 12, MinAccuracy: 90},
+	{ID: "top-review", Title: "Top row review", MinWPM: 18, MinAccuracy: 92},
+	{ID: "bottom-vm", Title: "Bottom row: index fingers", Keys: "vm", MinWPM: 15, MinAccuracy: 90},
+	{ID: "bottom-c", Title: "Bottom row: middle fingers", Keys: "c,", MinWPM: 15, MinAccuracy: 90},
+	{ID: "bottom-x", Title: "Bottom row: ring fingers", Keys: "x.", MinWPM: 15, MinAccuracy: 90},
+	{ID: "bottom-z", Title: "Bottom row: pinkies", Keys: "z/", MinWPM: 15, MinAccuracy: 90},
+	{ID: "bottom-bn", Title: "Bottom row: index reach", Keys: "bn", MinWPM: 15, MinAccuracy: 90},
+	{ID: "letters-review", Title: "All letters review", MinWPM: 25, MinAccuracy: 95},
+	{ID: "numbers-left", Title: "Number row: left hand", Keys: "12345", MinWPM: 20, MinAccuracy: 90},
+	{ID: "numbers-right", Title: "Number row: right hand", Keys: "67890", MinWPM: 20, MinAccuracy: 90},
+}
+
+// LessonResult is the best attempt at a lesson.
+type LessonResult struct {
+	BestWPM      float64 `json:"best_wpm"`
+	BestAccuracy float64 `json:"best_accuracy"`
+	Passed       bool    `json:"passed"`
+	Attempts     int     `json:"attempts"`
+}
+
+// LessonProgress is persisted per keyboard layout, someone switching to
+// Colemak starts the curriculum over without losing their QWERTY progress.
+type LessonProgress struct {
+	Layouts map[string]map[string]LessonResult `json:"layouts"`
+}
+
+func GetLessonProgressFilePath() (string, error) {
+	configDir, err := utils.GetAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "lessons.json"), nil
+}
+
+func LoadLessonProgress() (*LessonProgress, error) {
+	progress := &LessonProgress{Layouts: make(map[string]map[string]LessonResult)}
+
+	progressPath, err := GetLessonProgressFilePath()
+	if err != nil {
+		return progress, fmt.Errorf("failed to get lesson progress file path: %w", err)
+	}
+
+	data, err := os.ReadFile(progressPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return progress, nil
+		}
+		return progress, fmt.Errorf("error reading lesson progress file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, progress); err != nil {
+		return progress, fmt.Errorf("error parsing lesson progress file: %w", err)
+	}
+
+	if progress.Layouts == nil {
+		progress.Layouts = make(map[string]map[string]LessonResult)
+	}
+
+	return progress, nil
+}
+
+func (p *LessonProgress) Save() error {
+	progressPath, err := GetLessonProgressFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get lesson progress file path: %w", err)
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling lesson progress: %w", err)
+	}
+
+	if err := os.WriteFile(progressPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing lesson progress file: %w", err)
+	}
+
+	return nil
+}
+
+// Result returns the stored result of a lesson for a layout.
+func (p *LessonProgress) Result(layout, lessonID string) LessonResult {
+	return p.Layouts[layout][lessonID]
+}
+
+// IsUnlocked reports whether a lesson can be played, the first lesson is
+// always open and every other one needs the previous lesson passed.
+func (p *LessonProgress) IsUnlocked(layout string, index int) bool {
+	if index <= 0 {
+		return true
+	}
+	if index >= len(Lessons) {
+		return false
+	}
+	return p.Result(layout, Lessons[index-1].ID).Passed
+}
+
+// Record stores an attempt, keeping the best numbers.
+func (p *LessonProgress) Record(layout, lessonID string, wpm, accuracy float64, passed bool) {
+	if p.Layouts[layout] == nil {
+		p.Layouts[layout] = make(map[string]LessonResult)
+	}
+
+	result := p.Layouts[layout][lessonID]
+	result.Attempts++
+	if wpm > result.BestWPM {
+		result.BestWPM = wpm
+	}
+	if accuracy > result.BestAccuracy {
+		result.BestAccuracy = accuracy
+	}
+	result.Passed = result.Passed || passed
+	p.Layouts[layout][lessonID] = result
+}
+
+// Passes reports whether a result meets the lesson's pass criteria.
+func (l Lesson) Passes(wpm, accuracy float64) bool {
+	return wpm >= l.MinWPM && accuracy >= l.MinAccuracy
+}
+
+// lessonKeys returns the keys introduced by a lesson and all keys unlocked so
+// far, translated to what they produce on the layout.
+func lessonKeys(index int, layout *KeyboardLayout) (newKeys, unlocked []rune) {
+	for i := 0; i <= index && i < len(Lessons); i++ {
+		keys := layout.Remap([]rune(Lessons[i].Keys))
+		unlocked = append(unlocked, keys...)
+		if i == index {
+			newKeys = keys
+		}
+	}
+	return newKeys, unlocked
+}
+
+// GenerateLessonDrill builds the text for a lesson: real words from the
+// language pack that only use unlocked keys, mixed with key groups that
+// drill the keys the lesson introduces.
+func GenerateLessonDrill(index int, layout *KeyboardLayout, pack *LanguagePack) string {
+	newKeys, unlocked := lessonKeys(index, layout)
+	if len(newKeys) == 0 {
+		newKeys = unlocked
+	}
+
+	allowed := make(map[rune]bool, len(unlocked))
+	for _, r := range unlocked {
+		allowed[r] = true
+	}
+
+	var words, focused []string
+	if pack != nil {
+		for _, word := range pack.Words {
+			lower := strings.ToLower(word)
+			if lower == "" || strings.ContainsFunc(lower, func(r rune) bool { return !allowed[r] }) {
+				continue
+			}
+			words = append(words, lower)
+			if strings.ContainsAny(lower, string(newKeys)) {
+				focused = append(focused, lower)
+			}
+		}
+	}
+
+	drill := make([]string, 0, lessonDrillWords)
+	for i := range lessonDrillWords {
+		switch {
+		case i%3 != 2 && len(focused) > 0:
+			drill = append(drill, focused[utils.RandomIntn(len(focused))])
+		case i%3 != 2 && len(words) > 0:
+			drill = append(drill, words[utils.RandomIntn(len(words))])
+		default:
+			drill = append(drill, keyGroup(newKeys, unlocked))
+		}
+	}
+
+	return strings.Join(drill, " ")
+}
+
+// keyGroup returns a short letter group, at least half of it new keys.
+func keyGroup(newKeys, unlocked []rune) string {
+	length := 3 + utils.RandomIntn(3)
+	group := make([]rune, length)
+	for i := range group {
+		if i%2 == 0 || len(unlocked) == 0 {
+			group[i] = newKeys[utils.RandomIntn(len(newKeys))]
+		} else {
+			group[i] = unlocked[utils.RandomIntn(len(unlocked))]
+		}
+	}
+
+	// NOTE: punctuation keys read badly at the start of a group, lead with a letter
+	if !unicode.IsLetter(group[0]) {
+		for i, r := range group {
+			if unicode.IsLetter(r) {
+				group[0], group[i] = group[i], group[0]
+				break
+			}
+		}
+	}
+
+	return string(group)
+}
+
+// KeysLabel renders the keys a lesson introduces on the given layout.
+func (l Lesson) KeysLabel(layout *KeyboardLayout) string {
+	if l.Keys == "" {
+		return "review"
+	}
+	keys := layout.Remap([]rune(l.Keys))
+	labels := make([]string, len(keys))
+	for i, r := range keys {
+		labels[i] = string(r)
+	}
+	return strings.Join(labels, " ")
+}
+
+// lessonLayoutName is the key lesson progress is stored under.
+func lessonLayoutName() string {
+	name := strings.ToLower(strings.TrimSpace(CurrentSettings.KeyboardLayout))
+	if name == "" {
+		return LayoutQwerty
+	}
+	return name
+}
+
+// NewLessonModel starts a typing game on a fresh drill for a lesson.
+func NewLessonModel(width, height, index int) *TypingModel {
+	mode := &lessonMode{index: index}
+	model := NewTypingModel(width, height, mode.GenerateText(CurrentSettings))
+	model.mode = mode
+	return model
+}
+
+// currentLessonIndex returns the first lesson not passed yet on the current
+// layout, the last one once the curriculum is done.
+func currentLessonIndex() int {
+	progress, err := LoadLessonProgress()
+	if err != nil {
+		devlog.Log("Lessons: %v", err)
+	}
+
+	layout := lessonLayoutName()
+	for i, lesson := range Lessons {
+		if !progress.Result(layout, lesson.ID).Passed {
+			return i
+		}
+	}
+	return len(Lessons) - 1
+}
+
+// recordLessonResult stores an attempt and reports whether it passed.
+func recordLessonResult(index int, wpm, accuracy float64) bool {
+	lesson := Lessons[index]
+	passed := lesson.Passes(wpm, accuracy)
+
+	progress, err := LoadLessonProgress()
+	if err != nil {
+		devlog.Log("Lessons: %v", err)
+	}
+	progress.Record(lessonLayoutName(), lesson.ID, wpm, accuracy, passed)
+	if err := progress.Save(); err != nil {
+		devlog.Log("Lessons: Could not save progress: %v", err)
+	}
+
+	return passed
+}
+
+// RunLesson runs a lesson until the user quits.
+func RunLesson(index int) {
+	if index < 0 || index >= len(Lessons) {
+		return
+	}
+
+	devlog.Log("Lessons: Starting lesson %s", Lessons[index].ID)
+
+	p := tea.NewProgram(NewLessonModel(0, 0, index), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("Error running lesson: %v\n", err)
+	}
+}
@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
@@ -24,6 +23,8 @@ type LoadingModel struct {
 	text        string
 	lastTick    time.Time
 	progressBar progress.Model
+
+	language string // name of the language pack, looked up once
 }
 
 func NewLoadingModel(customText string) *LoadingModel {
@@ -39,6 +40,7 @@ func NewLoadingModel(customText string) *LoadingModel {
 		lastTick:    time.Now(),
 		progressBar: p,
 		text:        customText,
+		language:    languageName(CurrentSettings.Language),
 	}
 }
 
@@ -85,7 +87,11 @@ func (m *LoadingModel) View() string {
 	centeredSpinner := lipgloss.NewStyle().Width(m.width * 3 / 4).Align(lipgloss.Center).Render(spinnerDisplay)
 	centeredLoadingText := lipgloss.NewStyle().Width(m.width * 3 / 4).Align(lipgloss.Center).Render("Loading text...")
 	centeredProgressBar := lipgloss.NewStyle().Width(m.width * 3 / 4).Align(lipgloss.Center).Render(progressBar)
-	centeredHelp := lipgloss.NewStyle().Width(m.width * 3 / 4).Align(lipgloss.Center).Render(HelpStyle("Fetching random text from https://zenquotes.io/api/random ..."))
+	source := "Fetching random text from https://zenquotes.io/api/random ..."
+	if CurrentSettings.Language != "" && CurrentSettings.Language != DefaultLanguage {
+		source = fmt.Sprintf("Picking a passage from the %s language pack ...", m.language)
+	}
+	centeredHelp := lipgloss.NewStyle().Width(m.width * 3 / 4).Align(lipgloss.Center).Render(HelpStyle(source))
 
 	content := "\n\n" +
 		centeredSpinner + "\n\n" +
@@ -99,46 +105,20 @@ func (m *LoadingModel) View() string {
 }
 
 func fetchTextCmd(customText string) tea.Cmd {
-	if customText != "" {
-		return func() tea.Msg {
-			if len(customText) > 300 {
-				customText = utils.FormatText(customText[:300])
-			}
-			return textFetchedMsg(customText)
-		}
-	}
-
 	return func() tea.Msg {
-		textCount := map[string]int{
-			TextLengthShort:    1,
-			TextLengthMedium:   2,
-			TextLengthLong:     3,
-			TextLengthVeryLong: 5,
-		}
-
-		count := textCount[CurrentSettings.TextLength]
-
-		texts := make([]string, 0, count)
-
-		estimatedTotalLen := count * 200
-
-		for range count {
-			text := GetRandomText()
-			texts = append(texts, text)
-		}
-
-		var finalTextBuilder strings.Builder
-		finalTextBuilder.Grow(estimatedTotalLen + count)
-
-		for i, text := range texts {
-			finalTextBuilder.WriteString(text)
-			if i < len(texts)-1 {
-				finalTextBuilder.WriteRune(' ')
-			}
-		}
+		return textFetchedMsg(GameText(customText))
+	}
+}
 
-		return textFetchedMsg(finalTextBuilder.String())
+// GameText returns the passage for a new game: the custom text if there is
+// one, otherwise a text from the active game mode. Custom text is only
+// cleaned up, the mode's transforms (injection, word limits, ...) are for
+// generated text.
+func GameText(customText string) string {
+	if customText != "" {
+		return utils.FormatText(utils.TruncateRunes(customText, 300))
 	}
+	return ActiveGameMode().GenerateText(CurrentSettings)
 }
 
 func StartLoading(cmd *cobra.Command, args []string) {
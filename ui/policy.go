@@ -0,0 +1,91 @@
+package ui
+
+import "strings"
+
+// ErrorPolicy decides what happens when a wrong key is typed.
+type ErrorPolicy string
+
+const (
+	ErrorPolicyFree         ErrorPolicy = "free"           // wrong letters are typed, space skips the rest of a word
+	ErrorPolicyStopOnLetter ErrorPolicy = "stop_on_letter" // the caret waits for the right key
+	ErrorPolicyStopOnWord   ErrorPolicy = "stop_on_word"   // a word with mistakes can't be left until it's fixed
+)
+
+// ErrorPolicies lists the policies in the order the settings cycle through them.
+var ErrorPolicies = []ErrorPolicy{ErrorPolicyFree, ErrorPolicyStopOnLetter, ErrorPolicyStopOnWord}
+
+// ParseErrorPolicy returns the policy named s, the free policy if s is unknown.
+func ParseErrorPolicy(s string) ErrorPolicy {
+	for _, policy := range ErrorPolicies {
+		if string(policy) == strings.ToLower(strings.TrimSpace(s)) {
+			return policy
+		}
+	}
+	return ErrorPolicyFree
+}
+
+// Title is the policy as shown in menus and results.
+func (p ErrorPolicy) Title() string {
+	switch p {
+	case ErrorPolicyStopOnLetter:
+		return "Stop on letter"
+	case ErrorPolicyStopOnWord:
+		return "Stop on word"
+	}
+	return "Free"
+}
+
+// Description explains the policy in the settings.
+func (p ErrorPolicy) Description() string {
+	switch p {
+	case ErrorPolicyStopOnLetter:
+		return "The caret won't move until the right key is pressed, wrong keys still count as mistakes."
+	case ErrorPolicyStopOnWord:
+		return "Space won't move past a word with mistakes, fix it with backspace first."
+	}
+	return "Keep typing past mistakes, space skips the rest of a word."
+}
+
+// BackspacePolicy decides how far backspace can walk back.
+type BackspacePolicy string
+
+const (
+	BackspaceFree BackspacePolicy = "free" // backspace can walk back into previous words
+	BackspaceWord BackspacePolicy = "word" // backspace only works within the current word
+	BackspaceOff  BackspacePolicy = "off"  // backspace is ignored, every keystroke is final
+)
+
+// BackspacePolicies lists the policies in the order the settings cycle through them.
+var BackspacePolicies = []BackspacePolicy{BackspaceFree, BackspaceWord, BackspaceOff}
+
+// ParseBackspacePolicy returns the policy named s, the free policy if s is unknown.
+func ParseBackspacePolicy(s string) BackspacePolicy {
+	for _, policy := range BackspacePolicies {
+		if string(policy) == strings.ToLower(strings.TrimSpace(s)) {
+			return policy
+		}
+	}
+	return BackspaceFree
+}
+
+// Title is the policy as shown in menus and results.
+func (p BackspacePolicy) Title() string {
+	switch p {
+	case BackspaceWord:
+		return "Current word only"
+	case BackspaceOff:
+		return "Disabled"
+	}
+	return "Free"
+}
+
+// Description explains the policy in the settings.
+func (p BackspacePolicy) Description() string {
+	switch p {
+	case BackspaceWord:
+		return "Backspace fixes the word you're on but can't go back to words you've left."
+	case BackspaceOff:
+		return "Backspace does nothing, commit to every keystroke."
+	}
+	return "Backspace can walk back into previous words."
+}
@@ -0,0 +1,115 @@
+package ui
+
+import "testing"
+
+func TestErrorPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    ErrorPolicy
+		backspace BackspacePolicy
+		keys      string
+		wantCaret Caret
+		wantStats [3]int // total, correct and wrong words
+		wantDone  bool
+	}{
+		{"free types wrong letters", ErrorPolicyFree, BackspaceFree, "ax cd", Caret{2, 2}, [3]int{2, 1, 1}, true},
+		{"free skips with space", ErrorPolicyFree, BackspaceFree, "a cd", Caret{2, 2}, [3]int{2, 1, 1}, true},
+		{"stop on letter waits", ErrorPolicyStopOnLetter, BackspaceFree, "ax", Caret{0, 1}, [3]int{2, 0, 1}, false},
+		{"stop on letter counts the mistake", ErrorPolicyStopOnLetter, BackspaceFree, "axb cd", Caret{2, 2}, [3]int{2, 1, 1}, true},
+		{"stop on letter ignores a leading space", ErrorPolicyStopOnLetter, BackspaceFree, " ab cd", Caret{2, 2}, [3]int{2, 2, 0}, true},
+		{"stop on letter refuses an early space", ErrorPolicyStopOnLetter, BackspaceFree, "a cd", Caret{0, 1}, [3]int{2, 0, 1}, false},
+		{"stop on letter blames a wrong space", ErrorPolicyStopOnLetter, BackspaceFree, "abx cd", Caret{2, 2}, [3]int{2, 1, 1}, true},
+		{"stop on word holds a wrong word", ErrorPolicyStopOnWord, BackspaceFree, "ax cd", Caret{0, 4}, [3]int{2, 0, 1}, false},
+		{"stop on word holds a short word", ErrorPolicyStopOnWord, BackspaceFree, "a cd", Caret{0, 3}, [3]int{2, 0, 1}, false},
+		{"stop on word after a fix", ErrorPolicyStopOnWord, BackspaceFree, "ax\bb cd", Caret{2, 2}, [3]int{2, 2, 0}, true},
+		{"stop on word without backspace", ErrorPolicyStopOnWord, BackspaceOff, "ax cd", Caret{2, 2}, [3]int{2, 1, 1}, true},
+		{"stop on word holds the last word", ErrorPolicyStopOnWord, BackspaceFree, "ab cx", Caret{2, 2}, [3]int{2, 1, 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := NewText("ab cd")
+			text.SetErrorPolicy(tt.policy)
+			text.SetBackspacePolicy(tt.backspace)
+			typeKeys(text, tt.keys)
+
+			if got := text.Caret(); got != tt.wantCaret {
+				t.Errorf("caret at %+v, want %+v", got, tt.wantCaret)
+			}
+			if total, correct, errors := text.Stats(); [3]int{total, correct, errors} != tt.wantStats {
+				t.Errorf("stats %d/%d/%d, want %d/%d/%d", total, correct, errors, tt.wantStats[0], tt.wantStats[1], tt.wantStats[2])
+			}
+			if got := text.Finished(); got != tt.wantDone {
+				t.Errorf("finished = %v, want %v", got, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestParseErrorPolicy(t *testing.T) {
+	tests := []struct {
+		s    string
+		want ErrorPolicy
+	}{
+		{"free", ErrorPolicyFree},
+		{"stop_on_letter", ErrorPolicyStopOnLetter},
+		{" Stop_On_Word ", ErrorPolicyStopOnWord},
+		{"", ErrorPolicyFree},
+		{"strict", ErrorPolicyFree},
+	}
+
+	for _, tt := range tests {
+		if got := ParseErrorPolicy(tt.s); got != tt.want {
+			t.Errorf("ParseErrorPolicy(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestBackspacePolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    BackspacePolicy
+		keys      string
+		wantCaret Caret
+	}{
+		{"free walks back into the previous word", BackspaceFree, "ab c\b\b\b", Caret{0, 1}},
+		{"word stays in the current word", BackspaceWord, "ab c\b\b\b", Caret{2, 0}},
+		{"word fixes the current word", BackspaceWord, "ax\bb", Caret{0, 2}},
+		{"off ignores backspace", BackspaceOff, "ab c\b\b\b", Caret{2, 1}},
+		{"free word delete clears the current word", BackspaceFree, "ab cd\x17", Caret{2, 0}},
+		{"free word delete walks back", BackspaceFree, "ab c\x17\x17", Caret{0, 0}},
+		{"word delete stays in the current word", BackspaceWord, "ab c\x17\x17", Caret{2, 0}},
+		{"off ignores word deletes", BackspaceOff, "ab c\x17", Caret{2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := NewText("ab cd")
+			text.SetBackspacePolicy(tt.policy)
+			typeKeys(text, tt.keys)
+
+			if got := text.Caret(); got != tt.wantCaret {
+				t.Errorf("caret at %+v, want %+v", got, tt.wantCaret)
+			}
+		})
+	}
+}
+
+func TestParseBackspacePolicy(t *testing.T) {
+	tests := []struct {
+		s    string
+		want BackspacePolicy
+	}{
+		{"free", BackspaceFree},
+		{"WORD", BackspaceWord},
+		{" off", BackspaceOff},
+		{"", BackspaceFree},
+		{"never", BackspaceFree},
+	}
+
+	for _, tt := range tests {
+		if got := ParseBackspacePolicy(tt.s); got != tt.want {
+			t.Errorf("ParseBackspacePolicy(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
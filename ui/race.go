@@ -0,0 +1,347 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/race"
+)
+
+const standingsBarWidth = 20
+
+type racePhase int
+
+const (
+	raceLobby racePhase = iota
+	raceCountdown
+	raceRacing
+	raceFinished
+)
+
+// raceMsg is a message from the race server.
+type raceMsg race.Message
+
+// raceErrMsg reports a lost connection or a refused join.
+type raceErrMsg struct{ err error }
+
+// RaceModel is a client in a network race: it waits in the lobby, counts
+// down with everyone else and then plays the passage in a TypingModel,
+// reporting progress and showing the other racers.
+type RaceModel struct {
+	client   *race.Client
+	phase    racePhase
+	racers   []race.Racer
+	seconds  int // countdown seconds left
+	game     *TypingModel
+	end      *EndGameModel
+	results  bool // the server sent the final rankings
+	err      error
+	width    int
+	height   int
+	lastTick time.Time
+}
+
+func NewRaceModel(client *race.Client) *RaceModel {
+	return &RaceModel{
+		client:   client,
+		phase:    raceLobby,
+		lastTick: time.Now(),
+	}
+}
+
+func (m *RaceModel) Init() tea.Cmd {
+	return tea.Batch(InitGlobalTick(), m.receive())
+}
+
+// receive waits for the next message from the server.
+func (m *RaceModel) receive() tea.Cmd {
+	return func() tea.Msg {
+		msg, err := m.client.Receive()
+		if err != nil {
+			return raceErrMsg{err}
+		}
+		return raceMsg(msg)
+	}
+}
+
+func (m *RaceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.game != nil {
+			m.game.Update(msg)
+		}
+		if m.end != nil {
+			m.end.Update(msg)
+		}
+		return m, nil
+
+	case GlobalTickMsg:
+		switch {
+		case m.phase == raceRacing:
+			return m.updateGame(msg)
+		case m.end != nil:
+			_, cmd := m.end.Update(msg)
+			return m, cmd
+		}
+		var cmd tea.Cmd
+		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
+		return m, cmd
+
+	case raceMsg:
+		return m.handleServer(race.Message(msg))
+
+	case raceErrMsg:
+		// NOTE: the server hangs up once the results are sent
+		if !m.results {
+			devlog.Log("Race: %v", msg.err)
+			m.err = msg.err
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+
+		if m.err != nil {
+			return m, tea.Quit
+		}
+
+		switch m.phase {
+		case raceRacing:
+			if msg.Type == tea.KeyTab {
+				return m, nil
+			}
+			return m.updateGame(msg)
+		case raceFinished:
+			switch msg.String() {
+			case "q", "enter", " ":
+				return m, tea.Quit
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// updateGame forwards msg to the typing game and reports the new progress,
+// or the final score once the game hands over to its end screen.
+func (m *RaceModel) updateGame(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.game.Update(msg)
+
+	if end, ok := next.(*EndGameModel); ok {
+		m.end = end
+		m.end.race = m.racers
+		m.end.racerID = m.client.ID
+		m.phase = raceFinished
+		if err := m.client.SendFinish(end.wpm, end.accuracy); err != nil {
+			m.err = err
+		}
+		return m, cmd
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok {
+		caret := m.game.text.Caret()
+		wpm := m.game.mode.Score(m.game.state()).WPM
+		if err := m.client.SendProgress(0, caret.Word, caret.Letter, m.game.text.Progress(), wpm); err != nil {
+			m.err = err
+		}
+	}
+	return m, cmd
+}
+
+func (m *RaceModel) handleServer(msg race.Message) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case race.MsgLobby:
+		m.racers = msg.Racers
+
+	case race.MsgCountdown:
+		if msg.Text != "" {
+			m.game = NewTypingModel(m.width, m.height, msg.Text)
+			m.game.mode = &raceMode{}
+			m.game.bots = nil
+			m.racers = msg.Racers
+			m.phase = raceCountdown
+		}
+		m.seconds = msg.Seconds
+
+	case race.MsgStart:
+		m.phase = raceRacing
+		if m.game != nil {
+			m.game.startClock(time.Now())
+		}
+
+	case race.MsgStandings:
+		m.racers = msg.Racers
+
+	case race.MsgResults:
+		m.racers = msg.Racers
+		m.results = true
+		if m.end != nil {
+			m.end.race = m.racers
+		}
+		return m, nil
+	}
+
+	if m.game != nil {
+		m.game.text.SetCarets(m.otherCarets())
+	}
+	if m.end != nil {
+		m.end.race = m.racers
+	}
+	return m, m.receive()
+}
+
+// otherCarets returns where the other racers are in the passage.
+func (m *RaceModel) otherCarets() []Caret {
+	var carets []Caret
+	for _, racer := range m.racers {
+		if racer.ID == m.client.ID || racer.Left || racer.Finished {
+			continue
+		}
+		carets = append(carets, Caret{Word: racer.Word, Letter: racer.Letter})
+	}
+	return carets
+}
+
+func (m *RaceModel) View() string {
+	if m.err != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			ErrorStyle.Render("Race error: "+m.err.Error())+"\n\n"+HelpStyle("Press any key to quit"))
+	}
+
+	switch m.phase {
+	case raceCountdown:
+		m.game.banner = TimerStyle.Render(fmt.Sprintf("Starting in %d...", m.seconds)) + "\n\n" + renderStandings(m.racers, m.client.ID)
+		return m.game.View()
+	case raceRacing:
+		m.game.banner = renderStandings(m.racers, m.client.ID)
+		return m.game.View()
+	case raceFinished:
+		return m.end.View()
+	}
+
+	return m.renderLobby()
+}
+
+func (m *RaceModel) renderLobby() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(GetColor("timer")).
+		Render("Race Lobby")
+
+	var names []string
+	for _, racer := range m.racers {
+		name := racer.Name
+		if racer.ID == m.client.ID {
+			name += " (you)"
+		}
+		names = append(names, lipgloss.NewStyle().Foreground(GetColor("text_preview")).Render("• "+name))
+	}
+
+	content := title + "\n\n" +
+		strings.Join(names, "\n") + "\n\n" +
+		HintStyle("Waiting for more racers to join, the race starts on its own.") + "\n\n" +
+		HelpStyle("Press ESC to leave")
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// renderStandings draws a progress bar per racer, leader first.
+func renderStandings(racers []race.Racer, self int) string {
+	nameWidth := 0
+	for _, racer := range racers {
+		nameWidth = max(nameWidth, lipgloss.Width(racer.Name))
+	}
+
+	lines := make([]string, 0, len(racers))
+	for _, racer := range racers {
+		barColor := GetColor("timer")
+		if racer.ID == self {
+			barColor = GetColor("text_correct")
+		}
+
+		filled := int(racer.Progress * standingsBarWidth)
+		bar := lipgloss.NewStyle().Foreground(barColor).Render(strings.Repeat("█", filled)) +
+			DimStyle.Render(strings.Repeat("░", standingsBarWidth-filled))
+
+		status := fmt.Sprintf("%5.1f WPM", racer.WPM)
+		switch {
+		case racer.Left:
+			status = "left"
+		case racer.Finished:
+			status += fmt.Sprintf("  #%d", racer.Place)
+		}
+
+		name := racer.Name + strings.Repeat(" ", nameWidth-lipgloss.Width(racer.Name))
+		lines = append(lines, fmt.Sprintf("%s %s %s", name, bar, HelpStyle(status)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderRankings is the race result on the end screen.
+func renderRankings(racers []race.Racer, self int) string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(GetColor("timer")).
+		Render("Race Rankings")
+
+	lines := []string{title}
+	for i, racer := range racers {
+		var line string
+		switch {
+		case racer.Finished:
+			line = fmt.Sprintf("%d. %s  %.1f WPM  %.1f%%", i+1, racer.Name, racer.WPM, racer.Accuracy)
+		case racer.Left:
+			line = fmt.Sprintf("-  %s  left the race", racer.Name)
+		default:
+			line = fmt.Sprintf("…  %s  still typing (%.0f%%)", racer.Name, racer.Progress*100)
+		}
+
+		style := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+		if racer.ID == self {
+			style = style.Foreground(GetColor("text_correct")).Bold(true)
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// raceMode plays a passage handed out by a race server. It is not
+// registered, races are joined with `go-typer join`.
+type raceMode struct{}
+
+func (m *raceMode) Name() string                              { return "race" }
+func (m *raceMode) Title() string                             { return "Race" }
+func (m *raceMode) Description() string                       { return "The passage of a network race" }
+func (m *raceMode) Pipeline(settings UserSettings) []string   { return nil }
+func (m *raceMode) GenerateText(settings UserSettings) string { return "" }
+func (m *raceMode) IsComplete(state GameState) bool           { return finishedText(state) }
+func (m *raceMode) Score(state GameState) GameResult          { return scoreText(state) }
+func (m *raceMode) HUD(state GameState) string                { return elapsedHUD(state) }
+
+// RunRace joins the race server at addr and plays the race.
+func RunRace(addr, name string) error {
+	client, err := race.Dial(addr, name)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	devlog.Log("Race: Joined %s as %s (%d)", addr, client.Name, client.ID)
+
+	model := NewRaceModel(client)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error running race: %w", err)
+	}
+	return model.err
+}
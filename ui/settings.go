@@ -17,15 +17,37 @@ type UserSettings struct {
 	CursorType     string `json:"cursor_type"`
 	GameMode       string `json:"game_mode"`
 	UseNumbers     bool   `json:"use_numbers"`
+	UsePunctuation bool   `json:"use_punctuation"`
 	TextLength     string `json:"text_length"`
+	Language       string `json:"language"`
+	KeyboardLayout string `json:"keyboard_layout"`
+	ShowKeyboard   bool   `json:"show_keyboard"`
 	HasSeenWelcome bool   `json:"has_seen_welcome"`
 	RefreshRate    int    `json:"refresh_rate"` // NOTE:in frames per second not tick
+	TimeLimit      int    `json:"time_limit"`   // seconds, for the timed mode
+	WordCount      int    `json:"word_count"`   // words, for the words mode
+	ErrorPolicy    string `json:"error_policy"` // free, stop_on_letter or stop_on_word
+
+	BackspacePolicy string `json:"backspace_policy"` // free, word or off
+
+	ASCIIQuotes     bool `json:"ascii_quotes"`     // map smart quotes and dashes to ASCII
+	StripDiacritics bool `json:"strip_diacritics"` // fold accented letters (é -> e)
+	TerminalBidi    bool `json:"terminal_bidi"`    // terminal reorders RTL text itself
+
+	NumberRate      float64 `json:"number_rate"`      // share of words followed by a number when UseNumbers is on
+	PunctuationRate float64 `json:"punctuation_rate"` // share of words given punctuation when UsePunctuation is on
+
+	Pipelines map[string][]string `json:"pipelines,omitempty"` // text transforms per game mode, replacing the defaults
+
+	WordDeleteKeys []string `json:"word_delete_keys"` // shortcuts that delete a whole word
+
+	CtrlHDeletesWord bool `json:"ctrl_h_deletes_word"` // the terminal sends Ctrl+Backspace as ctrl+h
+
+	LeaderboardURL  string `json:"leaderboard_url,omitempty"`  // leaderboard server results are submitted to
+	LeaderboardUser string `json:"leaderboard_user,omitempty"` // name shown on the leaderboard, $USER if empty
 }
 
 const (
-	GameModeNormal = "normal"
-	GameModeSimple = "simple"
-
 	TextLengthShort    = "short"
 	TextLengthMedium   = "medium"
 	TextLengthLong     = "long"
@@ -38,8 +60,23 @@ var DefaultSettings = UserSettings{
 	GameMode:       GameModeNormal,
 	UseNumbers:     true,
 	TextLength:     TextLengthShort,
+	Language:       DefaultLanguage,
+	KeyboardLayout: LayoutQwerty,
 	HasSeenWelcome: false,
 	RefreshRate:    10,
+	TimeLimit:      60,
+	WordCount:      50,
+	ErrorPolicy:    string(ErrorPolicyFree),
+
+	BackspacePolicy: string(BackspaceFree),
+
+	ASCIIQuotes:     true,
+	StripDiacritics: false,
+
+	NumberRate:      0.1,
+	PunctuationRate: 0.15,
+
+	WordDeleteKeys: DefaultWordDeleteKeys,
 }
 
 var CurrentSettings UserSettings
@@ -113,14 +150,72 @@ func UpdateSettings(settings UserSettings) error {
 		CurrentSettings.UseNumbers = settings.UseNumbers
 	}
 
+	if settings.UsePunctuation != CurrentSettings.UsePunctuation {
+		CurrentSettings.UsePunctuation = settings.UsePunctuation
+	}
+
 	if settings.TextLength != "" {
 		CurrentSettings.TextLength = settings.TextLength
 	}
 
+	if settings.Language != "" {
+		CurrentSettings.Language = settings.Language
+	}
+
+	if settings.KeyboardLayout != "" {
+		CurrentSettings.KeyboardLayout = settings.KeyboardLayout
+	}
+
+	if settings.ErrorPolicy != "" {
+		CurrentSettings.ErrorPolicy = settings.ErrorPolicy
+	}
+
+	if settings.BackspacePolicy != "" {
+		CurrentSettings.BackspacePolicy = settings.BackspacePolicy
+	}
+
 	if settings.RefreshRate > 0 {
 		CurrentSettings.RefreshRate = settings.RefreshRate
 	}
 
+	if settings.TimeLimit > 0 {
+		CurrentSettings.TimeLimit = settings.TimeLimit
+	}
+
+	if settings.WordCount > 0 {
+		CurrentSettings.WordCount = settings.WordCount
+	}
+
+	if settings.NumberRate > 0 {
+		CurrentSettings.NumberRate = settings.NumberRate
+	}
+
+	if settings.PunctuationRate > 0 {
+		CurrentSettings.PunctuationRate = settings.PunctuationRate
+	}
+
+	CurrentSettings.ASCIIQuotes = settings.ASCIIQuotes
+	CurrentSettings.StripDiacritics = settings.StripDiacritics
+	CurrentSettings.TerminalBidi = settings.TerminalBidi
+	CurrentSettings.ShowKeyboard = settings.ShowKeyboard
+	CurrentSettings.CtrlHDeletesWord = settings.CtrlHDeletesWord
+
+	if settings.Pipelines != nil {
+		CurrentSettings.Pipelines = settings.Pipelines
+	}
+
+	if settings.WordDeleteKeys != nil {
+		CurrentSettings.WordDeleteKeys = settings.WordDeleteKeys
+	}
+
+	if settings.LeaderboardURL != "" {
+		CurrentSettings.LeaderboardURL = settings.LeaderboardURL
+	}
+
+	if settings.LeaderboardUser != "" {
+		CurrentSettings.LeaderboardUser = settings.LeaderboardUser
+	}
+
 	ApplySettings()
 
 	return SaveSettings()
@@ -140,6 +235,8 @@ func ApplySettings() {
 }
 
 func InitSettings() {
+	InitLayouts()
+
 	if err := LoadSettings(); err != nil {
 		fmt.Printf("Warning: Could not load settings: %v\n", err)
 		fmt.Println("Using default settings")
@@ -197,7 +294,7 @@ func createSettingsItems(settings UserSettings) []list.Item {
 		}
 	}
 
-	gameModeOptions := []string{GameModeNormal, GameModeSimple}
+	gameModeOptions := GameModeNames()
 	gameModeSelected := 0
 	for i, opt := range gameModeOptions {
 		if opt == settings.GameMode {
@@ -215,6 +312,78 @@ func createSettingsItems(settings UserSettings) []list.Item {
 		}
 	}
 
+	languageOptions := ListAvailableLanguages()
+	languageSelected := 0
+	for i, opt := range languageOptions {
+		if opt == settings.Language {
+			languageSelected = i
+			break
+		}
+	}
+
+	layoutOptions := ListAvailableLayouts()
+	layoutSelected := 0
+	for i, opt := range layoutOptions {
+		if opt == settings.KeyboardLayout {
+			layoutSelected = i
+			break
+		}
+	}
+
+	punctuationOptions := []string{"off", "on"}
+	punctuationSelected := 0
+	if settings.UsePunctuation {
+		punctuationSelected = 1
+	}
+
+	keyboardOptions := []string{"hidden", "shown"}
+	keyboardSelected := 0
+	if settings.ShowKeyboard {
+		keyboardSelected = 1
+	}
+
+	ctrlHOptions := []string{"backspace", "delete word"}
+	ctrlHSelected := 0
+	if settings.CtrlHDeletesWord {
+		ctrlHSelected = 1
+	}
+
+	timeLimitOptions := []string{"15", "30", "60", "120"}
+	timeLimitSelected := 0
+	for i, opt := range timeLimitOptions {
+		if opt == fmt.Sprintf("%d", settings.TimeLimit) {
+			timeLimitSelected = i
+			break
+		}
+	}
+
+	wordCountOptions := []string{"10", "25", "50", "100"}
+	wordCountSelected := 0
+	for i, opt := range wordCountOptions {
+		if opt == fmt.Sprintf("%d", settings.WordCount) {
+			wordCountSelected = i
+			break
+		}
+	}
+
+	errorPolicyOptions := make([]string, len(ErrorPolicies))
+	errorPolicySelected := 0
+	for i, policy := range ErrorPolicies {
+		errorPolicyOptions[i] = string(policy)
+		if policy == ParseErrorPolicy(settings.ErrorPolicy) {
+			errorPolicySelected = i
+		}
+	}
+
+	backspaceOptions := make([]string, len(BackspacePolicies))
+	backspaceSelected := 0
+	for i, policy := range BackspacePolicies {
+		backspaceOptions[i] = string(policy)
+		if policy == ParseBackspacePolicy(settings.BackspacePolicy) {
+			backspaceSelected = i
+		}
+	}
+
 	refreshRateOptions := []string{"5", "10", "15", "20", "30"}
 	refreshRateSelected := 0
 	for i, opt := range refreshRateOptions {
@@ -242,10 +411,52 @@ func createSettingsItems(settings UserSettings) []list.Item {
 		&SettingsItem{
 			title:    "Game Mode",
 			options:  gameModeOptions,
-			details:  "Select game difficulty mode",
+			details:  "Select what to type and how the game ends",
 			selected: gameModeSelected,
 			key:      "game_mode",
 		},
+		&SettingsItem{
+			title:    "Time Limit",
+			options:  timeLimitOptions,
+			details:  "Seconds per game in timed mode",
+			selected: timeLimitSelected,
+			key:      "time_limit",
+		},
+		&SettingsItem{
+			title:    "Word Count",
+			options:  wordCountOptions,
+			details:  "Words per game in words mode",
+			selected: wordCountSelected,
+			key:      "word_count",
+		},
+		&SettingsItem{
+			title:    "Error Policy",
+			options:  errorPolicyOptions,
+			details:  "What happens when a wrong key is typed",
+			selected: errorPolicySelected,
+			key:      "error_policy",
+		},
+		&SettingsItem{
+			title:    "Backspace",
+			options:  backspaceOptions,
+			details:  "How far backspace can walk back",
+			selected: backspaceSelected,
+			key:      "backspace_policy",
+		},
+		&SettingsItem{
+			title:    "Ctrl+H",
+			options:  ctrlHOptions,
+			details:  "Most terminals send Ctrl+Backspace as ctrl+h, leave it a backspace if yours sends it for Backspace",
+			selected: ctrlHSelected,
+			key:      "ctrl_h_deletes_word",
+		},
+		&SettingsItem{
+			title:    "Punctuation",
+			options:  punctuationOptions,
+			details:  "Add commas, periods, quotes, brackets and capitals to word lists",
+			selected: punctuationSelected,
+			key:      "use_punctuation",
+		},
 		&SettingsItem{
 			title:    "Text Length",
 			options:  textLengthOptions,
@@ -253,6 +464,27 @@ func createSettingsItems(settings UserSettings) []list.Item {
 			selected: textLengthSelected,
 			key:      "text_length",
 		},
+		&SettingsItem{
+			title:    "Language",
+			options:  languageOptions,
+			details:  "Language of the words and quotes",
+			selected: languageSelected,
+			key:      "language",
+		},
+		&SettingsItem{
+			title:    "Keyboard Layout",
+			options:  layoutOptions,
+			details:  "Emulate a layout on a QWERTY keyboard",
+			selected: layoutSelected,
+			key:      "keyboard_layout",
+		},
+		&SettingsItem{
+			title:    "On-screen Keyboard",
+			options:  keyboardOptions,
+			details:  "Show next key and finger hints below the text",
+			selected: keyboardSelected,
+			key:      "show_keyboard",
+		},
 		&SettingsItem{
 			title:    "Refresh Rate",
 			options:  refreshRateOptions,
@@ -305,8 +537,26 @@ func (m SettingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.settings.CursorType = i.options[i.selected]
 					case "game_mode":
 						m.settings.GameMode = i.options[i.selected]
+					case "error_policy":
+						m.settings.ErrorPolicy = i.options[i.selected]
+					case "backspace_policy":
+						m.settings.BackspacePolicy = i.options[i.selected]
+					case "use_punctuation":
+						m.settings.UsePunctuation = i.options[i.selected] == "on"
 					case "text_length":
 						m.settings.TextLength = i.options[i.selected]
+					case "language":
+						m.settings.Language = i.options[i.selected]
+					case "keyboard_layout":
+						m.settings.KeyboardLayout = i.options[i.selected]
+					case "show_keyboard":
+						m.settings.ShowKeyboard = i.options[i.selected] == "shown"
+					case "ctrl_h_deletes_word":
+						m.settings.CtrlHDeletesWord = i.options[i.selected] == "delete word"
+					case "time_limit":
+						fmt.Sscanf(i.options[i.selected], "%d", &m.settings.TimeLimit)
+					case "word_count":
+						fmt.Sscanf(i.options[i.selected], "%d", &m.settings.WordCount)
 					case "refresh_rate":
 						fmt.Sscanf(i.options[i.selected], "%d", &m.settings.RefreshRate)
 					}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -27,6 +28,9 @@ const logoArt = `
 const (
 	MenuMain     int = iota // MenuMain represents the main menu state
 	MenuSettings            // MenuSettings represents the settings menu state
+	MenuLessons             // MenuLessons represents the lesson list
+
+	lessonsVisible = 10 // Lessons shown at once in the lesson list
 
 	DisabledColor = "#555555" // Color for disabled menu items
 )
@@ -52,10 +56,21 @@ type StartScreenModel struct {
 	themeChanged    bool       // flag to indicate if the theme has changed
 	gameMode        string     // current game mode
 	useNumbers      bool       // flag to indicate if numbers are used
+	usePunctuation  bool       // flag to indicate if punctuation is added to word lists
 	textLength      string     // current text length
+	language        string     // current language pack code
+	keyboardLayout  string     // current emulated keyboard layout
+	showKeyboard    bool       // flag to indicate if the on-screen keyboard is shown
+	errorPolicy     string     // what happens when a wrong key is typed
+	backspace       string     // how far backspace can walk back
+	ctrlHDeletes    bool       // flag to indicate if ctrl+h deletes a word
 	refreshRate     int        // current refresh rate
 	startTime       time.Time  // time when the start screen was opened
 	lastTick        time.Time  // last tick time for animations
+
+	lessonItems    []menuItem      // list of lessons, locked ones disabled
+	lessonProgress *LessonProgress // saved lesson results
+	lessonToStart  int             // lesson picked from the list, -1 if none
 }
 
 func NewStartScreenModel() *StartScreenModel {
@@ -72,10 +87,21 @@ func NewStartScreenModel() *StartScreenModel {
 		themeChanged:    false,
 		gameMode:        CurrentSettings.GameMode,
 		useNumbers:      CurrentSettings.UseNumbers,
+		usePunctuation:  CurrentSettings.UsePunctuation,
 		textLength:      CurrentSettings.TextLength,
+		language:        CurrentSettings.Language,
+		keyboardLayout:  CurrentSettings.KeyboardLayout,
+		showKeyboard:    CurrentSettings.ShowKeyboard,
+		errorPolicy:     string(ParseErrorPolicy(CurrentSettings.ErrorPolicy)),
+		backspace:       string(ParseBackspacePolicy(CurrentSettings.BackspacePolicy)),
+		ctrlHDeletes:    CurrentSettings.CtrlHDeletesWord,
 		refreshRate:     CurrentSettings.RefreshRate,
+		lessonToStart:   -1,
 		mainMenuItems: []menuItem{
 			{title: "Start Typing", action: startGame},
+			{title: "Daily", action: startGame},
+			{title: "Lessons", action: openLessons},
+			{title: "Local Duel", action: startGame},
 			{title: "Multiplayer Typeracer", action: nil, disabled: true, backColor: DisabledColor},
 			{title: "Settings", action: openSettings},
 			{title: "Statistics", action: openStats, disabled: true, backColor: DisabledColor},
@@ -86,8 +112,15 @@ func NewStartScreenModel() *StartScreenModel {
 			{title: "Cursor Style", action: cycleCursor},
 			{title: "Game Mode", action: cycleGameMode},
 			{title: "Use Numbers", action: toggleNumbers},
+			{title: "Punctuation", action: togglePunctuation},
 			{title: "Text Length", action: cycleTextLength},
 			{title: "Refresh Rate", action: cycleRefreshRate},
+			{title: "Language", action: cycleLanguage},
+			{title: "Keyboard Layout", action: cycleKeyboardLayout},
+			{title: "Show Keyboard", action: toggleKeyboard},
+			{title: "Error Policy", action: cycleErrorPolicy},
+			{title: "Backspace", action: cycleBackspacePolicy},
+			{title: "Ctrl+H", action: toggleCtrlH},
 			{title: "Back", action: saveAndGoBack},
 		},
 		startTime: time.Now(),
@@ -128,65 +161,39 @@ func (m *StartScreenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case "up", "k":
+			items := m.currentItems()
 			previousItem := m.selectedItem
 			for {
 				m.selectedItem--
 
-				if m.menuState == MenuMain {
-					// Wrap to bottom if at top
-					if m.selectedItem < 0 {
-						m.selectedItem = len(m.mainMenuItems) - 1
-					}
-					// Break if item is enabled or we're back at starting point
-					if !m.mainMenuItems[m.selectedItem].disabled || m.selectedItem == previousItem {
-						break
-					}
-				} else {
-					// Wrap to bottom if at top
-					if m.selectedItem < 0 {
-						m.selectedItem = len(m.settingsItems) - 1
-					}
-					// Break if item is enabled or we're back at starting point
-					if !m.settingsItems[m.selectedItem].disabled || m.selectedItem == previousItem {
-						break
-					}
+				// Wrap to bottom if at top
+				if m.selectedItem < 0 {
+					m.selectedItem = len(items) - 1
+				}
+				// Break if item is enabled or we're back at starting point
+				if !items[m.selectedItem].disabled || m.selectedItem == previousItem {
+					break
 				}
 			}
 
 		case "down", "j":
+			items := m.currentItems()
 			previousItem := m.selectedItem
 			for {
 				m.selectedItem++
 
-				if m.menuState == MenuMain {
-					// Wrap around to start if we reach the end
-					if m.selectedItem >= len(m.mainMenuItems) {
-						m.selectedItem = 0
-					}
-					// Stop if we find enabled item or get back to starting point
-					if !m.mainMenuItems[m.selectedItem].disabled || m.selectedItem == previousItem {
-						break
-					}
-				} else {
-					// Wrap around to start if we reach the end
-					if m.selectedItem >= len(m.settingsItems) {
-						m.selectedItem = 0
-					}
-					// Stop if we find enabled item or get back to starting point
-					if !m.settingsItems[m.selectedItem].disabled || m.selectedItem == previousItem {
-						break
-					}
+				// Wrap around to start if we reach the end
+				if m.selectedItem >= len(items) {
+					m.selectedItem = 0
+				}
+				// Stop if we find enabled item or get back to starting point
+				if !items[m.selectedItem].disabled || m.selectedItem == previousItem {
+					break
 				}
 			}
 
 		case "enter", " ":
-			var items []menuItem
-			if m.menuState == MenuMain {
-				items = m.mainMenuItems
-			} else {
-				items = m.settingsItems
-			}
-
+			items := m.currentItems()
 			if m.selectedItem < len(items) && !items[m.selectedItem].disabled {
 				return m, items[m.selectedItem].action(m)
 			}
@@ -223,6 +230,17 @@ func (m *StartScreenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// currentItems returns the items of the menu being shown.
+func (m *StartScreenModel) currentItems() []menuItem {
+	switch m.menuState {
+	case MenuSettings:
+		return m.settingsItems
+	case MenuLessons:
+		return m.lessonItems
+	}
+	return m.mainMenuItems
+}
+
 func (m *StartScreenModel) View() string {
 	var menuContent string
 
@@ -233,6 +251,8 @@ func (m *StartScreenModel) View() string {
 			m.renderMainMenu())
 	case MenuSettings:
 		menuContent = m.renderSettingsMenu()
+	case MenuLessons:
+		menuContent = m.renderLessonsMenu()
 	}
 
 	footer := "\n" + HelpStyle("↑/↓: Navigate • Enter: Select • Esc: Back • q: Quit")
@@ -292,6 +312,69 @@ func (m *StartScreenModel) renderMainMenu() string {
 	return sb.String()
 }
 
+// renderLessonsMenu renders the curriculum with each lesson's status and the
+// pass criteria of the selected one.
+func (m *StartScreenModel) renderLessonsMenu() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(GetColor("timer")).
+		Bold(true).
+		Margin(1, 0, 2, 0)
+
+	sb.WriteString(titleStyle.Render("Lessons"))
+	sb.WriteString("\n\n")
+
+	layout, _ := LoadKeyboardLayout(CurrentSettings.KeyboardLayout)
+	layoutName := lessonLayoutName()
+
+	// NOTE: the curriculum is longer than most terminals, show a window around the selection
+	first := max(0, min(m.selectedItem-lessonsVisible/2, len(m.lessonItems)-lessonsVisible))
+	last := min(len(m.lessonItems), first+lessonsVisible)
+
+	for i := first; i < last; i++ {
+		item := m.lessonItems[i]
+		lesson := Lessons[i]
+
+		status := "  "
+		if m.lessonProgress.Result(layoutName, lesson.ID).Passed {
+			status = "✓ "
+		}
+
+		var s lipgloss.Style
+		if i == m.selectedItem {
+			s = lipgloss.NewStyle().
+				Foreground(GetColor("cursor_bg")).
+				Bold(true).
+				Underline(true)
+		} else if item.disabled {
+			s = lipgloss.NewStyle().Foreground(lipgloss.Color(item.backColor))
+		} else {
+			s = lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+		}
+
+		line := fmt.Sprintf("%s%2d. %s", status, i+1, item.title)
+		sb.WriteString(lipgloss.NewStyle().Width(40).Render(s.Render(line)))
+		sb.WriteString(HelpStyle(lesson.KeysLabel(layout)))
+		sb.WriteString("\n")
+	}
+
+	if m.selectedItem < len(Lessons) {
+		lesson := Lessons[m.selectedItem]
+		result := m.lessonProgress.Result(layoutName, lesson.ID)
+
+		details := fmt.Sprintf("Pass: %.0f WPM, %.0f%% accuracy", lesson.MinWPM, lesson.MinAccuracy)
+		if result.Attempts > 0 {
+			details += fmt.Sprintf(" • Best: %.1f WPM, %.1f%% • Attempts: %d", result.BestWPM, result.BestAccuracy, result.Attempts)
+		}
+
+		sb.WriteString("\n")
+		sb.WriteString(SettingsStyle(details))
+	}
+
+	return sb.String()
+}
+
 // renderSettingsMenu renders the settings menu with the style applied to each item.
 func (m *StartScreenModel) renderSettingsMenu() string {
 	var sb strings.Builder
@@ -316,9 +399,23 @@ func (m *StartScreenModel) renderSettingsMenu() string {
 	case 3:
 		exampleContent = renderUseNumbersExample(m.useNumbers)
 	case 4:
-		exampleContent = renderTextLengthExample(m.textLength)
+		exampleContent = renderUsePunctuationExample(m.usePunctuation)
 	case 5:
+		exampleContent = renderTextLengthExample(m.textLength)
+	case 6:
 		exampleContent = renderRefreshRateExample(m.refreshRate, m.lastTick)
+	case 7:
+		exampleContent = renderLanguageExample(m.language)
+	case 8:
+		exampleContent = renderKeyboardLayoutExample(m.keyboardLayout)
+	case 9:
+		exampleContent = renderShowKeyboardExample(m.showKeyboard, m.keyboardLayout)
+	case 10:
+		exampleContent = renderErrorPolicyExample(m.errorPolicy)
+	case 11:
+		exampleContent = renderBackspacePolicyExample(m.backspace)
+	case 12:
+		exampleContent = renderCtrlHExample(m.ctrlHDeletes)
 	}
 
 	var settingsList []string
@@ -349,9 +446,23 @@ func (m *StartScreenModel) renderSettingsMenu() string {
 		case 3:
 			menuText = fmt.Sprintf("%-15s: %v", item.title, m.useNumbers)
 		case 4:
-			menuText = fmt.Sprintf("%-15s: %s", item.title, m.textLength)
+			menuText = fmt.Sprintf("%-15s: %v", item.title, m.usePunctuation)
 		case 5:
+			menuText = fmt.Sprintf("%-15s: %s", item.title, m.textLength)
+		case 6:
 			menuText = fmt.Sprintf("%-15s: %d FPS", item.title, m.refreshRate)
+		case 7:
+			menuText = fmt.Sprintf("%-15s: %s", item.title, m.language)
+		case 8:
+			menuText = fmt.Sprintf("%-15s: %s", item.title, m.keyboardLayout)
+		case 9:
+			menuText = fmt.Sprintf("%-15s: %v", item.title, m.showKeyboard)
+		case 10:
+			menuText = fmt.Sprintf("%-15s: %s", item.title, m.errorPolicy)
+		case 11:
+			menuText = fmt.Sprintf("%-15s: %s", item.title, m.backspace)
+		case 12:
+			menuText = fmt.Sprintf("%-15s: %v", item.title, m.ctrlHDeletes)
 		}
 
 		settingsList = append(settingsList, s.Render(menuText))
@@ -369,9 +480,23 @@ func (m *StartScreenModel) renderSettingsMenu() string {
 		case 3:
 			exampleBox = renderUseNumbersExample(m.useNumbers)
 		case 4:
-			exampleBox = renderTextLengthExample(m.textLength)
+			exampleBox = renderUsePunctuationExample(m.usePunctuation)
 		case 5:
+			exampleBox = renderTextLengthExample(m.textLength)
+		case 6:
 			exampleBox = renderRefreshRateExample(m.refreshRate, m.lastTick)
+		case 7:
+			exampleBox = renderLanguageExample(m.language)
+		case 8:
+			exampleBox = renderKeyboardLayoutExample(m.keyboardLayout)
+		case 9:
+			exampleBox = renderShowKeyboardExample(m.showKeyboard, m.keyboardLayout)
+		case 10:
+			exampleBox = renderErrorPolicyExample(m.errorPolicy)
+		case 11:
+			exampleBox = renderBackspacePolicyExample(m.backspace)
+		case 12:
+			exampleBox = renderCtrlHExample(m.ctrlHDeletes)
 		}
 	}
 
@@ -476,21 +601,15 @@ func renderGameModeExample(gameMode string) string {
 	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
 	example.WriteString(titleStyle.Render("Game Mode: "))
 
+	mode := LookupGameMode(gameMode)
 	modeStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
-	if gameMode == "normal" {
-		example.WriteString(modeStyle.Render("Normal (With Punctuation)"))
-	} else {
-		example.WriteString(modeStyle.Render("Simple (No Punctuation)"))
-	}
+	example.WriteString(modeStyle.Render(mode.Title()))
 	example.WriteString("\n\n")
 
-	example.WriteString(titleStyle.Render("Example:\n"))
+	example.WriteString(TextToTypeStyle.Render(mode.Description()))
+	example.WriteString("\n\n")
 
-	if gameMode == "normal" {
-		example.WriteString(TextToTypeStyle.Render("The quick brown fox jumps."))
-	} else {
-		example.WriteString(TextToTypeStyle.Render("the quick brown fox jumps"))
-	}
+	example.WriteString(HelpStyle("Modes: " + strings.Join(GameModeNames(), ", ")))
 
 	return example.String()
 }
@@ -520,6 +639,34 @@ func renderUseNumbersExample(useNumbers bool) string {
 	return example.String()
 }
 
+func renderUsePunctuationExample(usePunctuation bool) string {
+	var example strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+	example.WriteString(titleStyle.Render("Punctuation: "))
+
+	valueStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+	if usePunctuation {
+		example.WriteString(valueStyle.Render("Yes"))
+	} else {
+		example.WriteString(valueStyle.Render("No"))
+	}
+	example.WriteString("\n\n")
+
+	example.WriteString(titleStyle.Render("Example:\n"))
+
+	if usePunctuation {
+		example.WriteString(TextToTypeStyle.Render("Quick brown fox, jumps over (lazy) dogs."))
+	} else {
+		example.WriteString(TextToTypeStyle.Render("quick brown fox jumps over lazy dogs"))
+	}
+
+	example.WriteString("\n\n")
+	example.WriteString(HelpStyle("Only word lists get punctuation, quotes keep their own."))
+
+	return example.String()
+}
+
 func renderTextLengthExample(length string) string {
 	var example strings.Builder
 
@@ -593,6 +740,181 @@ func renderRefreshRateExample(rate int, tickTime time.Time) string {
 	return sb.String()
 }
 
+func renderLanguageExample(code string) string {
+	var example strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+	example.WriteString(titleStyle.Render("Language: "))
+
+	valueStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+
+	pack, err := LoadLanguagePack(code)
+	if err != nil {
+		example.WriteString(valueStyle.Render(code))
+		example.WriteString("\n\n")
+		example.WriteString(lipgloss.NewStyle().Foreground(GetColor("text_error")).Render(err.Error()))
+		return example.String()
+	}
+
+	example.WriteString(valueStyle.Render(fmt.Sprintf("%s (%s)", pack.Name, pack.Code)))
+	example.WriteString("\n\n")
+
+	example.WriteString(titleStyle.Render("Pack contents:\n"))
+	example.WriteString(fmt.Sprintf("\n%d words, %d quotes", len(pack.Words), len(pack.Quotes)))
+	example.WriteString("\n\n")
+
+	example.WriteString(titleStyle.Render("Example:\n"))
+	example.WriteString(TextToTypeStyle.Render(strings.Join(pack.Words[:min(len(pack.Words), 8)], " ")))
+
+	descStyle := lipgloss.NewStyle().Foreground(GetColor("text_dim"))
+	example.WriteString("\n")
+	example.WriteString(descStyle.Render("Add your own packs to " + GetLanguagesDirPath()))
+
+	return example.String()
+}
+
+func renderKeyboardLayoutExample(name string) string {
+	var example strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+	example.WriteString(titleStyle.Render("Keyboard Layout: "))
+
+	valueStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+
+	layout, err := LoadKeyboardLayout(name)
+	if err != nil {
+		example.WriteString(valueStyle.Render(name))
+		example.WriteString("\n\n")
+		example.WriteString(lipgloss.NewStyle().Foreground(GetColor("text_error")).Render(err.Error()))
+		return example.String()
+	}
+
+	example.WriteString(valueStyle.Render(layout.Name))
+	example.WriteString("\n\n")
+
+	example.WriteString(titleStyle.Render("Keys:\n"))
+	for i, row := range layout.Rows {
+		example.WriteString("\n" + strings.Repeat(" ", i) + TextToTypeStyle.UnsetPadding().UnsetWidth().Render(strings.Join(strings.Split(row, ""), " ")))
+	}
+	example.WriteString("\n\n")
+
+	descStyle := lipgloss.NewStyle().Foreground(GetColor("text_dim"))
+	if layout.IsEmulated() {
+		example.WriteString(descStyle.Render("Keys typed on your QWERTY keyboard are remapped to this layout.\n"))
+	} else {
+		example.WriteString(descStyle.Render("Keys are used as typed.\n"))
+	}
+	example.WriteString(descStyle.Render("Layout files live in " + GetLayoutsDirPath()))
+
+	return example.String()
+}
+
+func renderShowKeyboardExample(showKeyboard bool, layoutName string) string {
+	var example strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+	example.WriteString(titleStyle.Render("Show Keyboard: "))
+
+	valueStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+	if showKeyboard {
+		example.WriteString(valueStyle.Render("Yes"))
+	} else {
+		example.WriteString(valueStyle.Render("No"))
+	}
+	example.WriteString("\n\n")
+
+	example.WriteString(titleStyle.Render("Example:\n\n"))
+
+	// NOTE: a broken layout file falls back to QWERTY for the preview
+	layout, _ := LoadKeyboardLayout(layoutName)
+	example.WriteString(RenderKeyboard(layout, "f", ""))
+	example.WriteString("\n\n")
+
+	descStyle := lipgloss.NewStyle().Foreground(GetColor("text_dim"))
+	example.WriteString(descStyle.Render("Ctrl+K toggles the keyboard while typing, it hides itself on small terminals."))
+
+	return example.String()
+}
+
+func renderErrorPolicyExample(policyName string) string {
+	var example strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+	descStyle := lipgloss.NewStyle().Foreground(GetColor("text_dim"))
+
+	policy := ParseErrorPolicy(policyName)
+	example.WriteString(titleStyle.Render("Error Policy: "))
+	example.WriteString(valueStyle.Render(policy.Title()))
+	example.WriteString("\n\n")
+	example.WriteString(descStyle.Render(policy.Description()))
+	example.WriteString("\n\n")
+
+	example.WriteString(titleStyle.Render("Policies:\n"))
+	for _, p := range ErrorPolicies {
+		marker := "  "
+		if p == policy {
+			marker = "> "
+		}
+		example.WriteString("\n" + valueStyle.Render(marker+p.Title()))
+	}
+
+	return example.String()
+}
+
+func renderBackspacePolicyExample(policyName string) string {
+	var example strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+	descStyle := lipgloss.NewStyle().Foreground(GetColor("text_dim"))
+
+	policy := ParseBackspacePolicy(policyName)
+	example.WriteString(titleStyle.Render("Backspace: "))
+	example.WriteString(valueStyle.Render(policy.Title()))
+	example.WriteString("\n\n")
+	example.WriteString(descStyle.Render(policy.Description()))
+	example.WriteString("\n\n")
+
+	example.WriteString(titleStyle.Render("Policies:\n"))
+	for _, p := range BackspacePolicies {
+		marker := "  "
+		if p == policy {
+			marker = "> "
+		}
+		example.WriteString("\n" + valueStyle.Render(marker+p.Title()))
+	}
+
+	return example.String()
+}
+
+func renderCtrlHExample(ctrlHDeletes bool) string {
+	var example strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Foreground(GetColor("timer")).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(GetColor("text_preview"))
+	descStyle := lipgloss.NewStyle().Foreground(GetColor("text_dim"))
+
+	example.WriteString(titleStyle.Render("Ctrl+H: "))
+	if ctrlHDeletes {
+		example.WriteString(valueStyle.Render("Deletes a word"))
+	} else {
+		example.WriteString(valueStyle.Render("Backspace"))
+	}
+	example.WriteString("\n\n")
+
+	example.WriteString(descStyle.Render("Most terminals send Ctrl+Backspace as ctrl+h. Turn this on to delete a word with Ctrl+Backspace, but leave it off if your terminal sends ctrl+h for a plain Backspace."))
+	example.WriteString("\n\n")
+	example.WriteString(titleStyle.Render("Word delete keys:\n"))
+	keys := CurrentSettings.WordDeleteKeys
+	if keys == nil {
+		keys = DefaultWordDeleteKeys
+	}
+	example.WriteString(valueStyle.Render(strings.Join(keys, ", ")))
+
+	return example.String()
+}
+
 func renderAnimatedAscii(logoArt string, tickTime time.Time) string {
 	var result strings.Builder
 	colors := []string{
@@ -633,6 +955,38 @@ func openSettings(m *StartScreenModel) tea.Cmd {
 	return nil
 }
 
+func openLessons(m *StartScreenModel) tea.Cmd {
+	progress, err := LoadLessonProgress()
+	if err != nil {
+		devlog.Log("Lessons: %v", err)
+	}
+	m.lessonProgress = progress
+
+	layout := lessonLayoutName()
+	m.lessonItems = make([]menuItem, len(Lessons))
+	m.selectedItem = 0
+	for i, lesson := range Lessons {
+		m.lessonItems[i] = menuItem{title: lesson.Title, action: startLesson}
+		if !progress.IsUnlocked(layout, i) {
+			m.lessonItems[i].disabled = true
+			m.lessonItems[i].backColor = DisabledColor
+			continue
+		}
+		// Start on the first lesson that hasn't been passed yet
+		if progress.Result(layout, lesson.ID).Passed && i+1 < len(Lessons) {
+			m.selectedItem = i + 1
+		}
+	}
+
+	m.menuState = MenuLessons
+	return nil
+}
+
+func startLesson(m *StartScreenModel) tea.Cmd {
+	m.lessonToStart = m.selectedItem
+	return tea.Quit
+}
+
 func openStats(m *StartScreenModel) tea.Cmd {
 	return nil
 }
@@ -641,18 +995,28 @@ func quitGame(m *StartScreenModel) tea.Cmd {
 	return tea.Quit
 }
 
-func saveAndGoBack(m *StartScreenModel) tea.Cmd {
-	settings := UserSettings{
-		ThemeName:      m.selectedTheme,
-		CursorType:     m.cursorType,
-		GameMode:       m.gameMode,
-		UseNumbers:     m.useNumbers,
-		TextLength:     m.textLength,
-		RefreshRate:    m.refreshRate,
-		HasSeenWelcome: CurrentSettings.HasSeenWelcome,
-	}
+// menuSettings are the current settings with the options of the settings
+// menu applied, everything the menu doesn't edit is kept as it is.
+func (m *StartScreenModel) menuSettings() UserSettings {
+	settings := CurrentSettings
+	settings.ThemeName = m.selectedTheme
+	settings.CursorType = m.cursorType
+	settings.GameMode = m.gameMode
+	settings.UseNumbers = m.useNumbers
+	settings.UsePunctuation = m.usePunctuation
+	settings.TextLength = m.textLength
+	settings.Language = m.language
+	settings.KeyboardLayout = m.keyboardLayout
+	settings.ShowKeyboard = m.showKeyboard
+	settings.ErrorPolicy = m.errorPolicy
+	settings.BackspacePolicy = m.backspace
+	settings.CtrlHDeletesWord = m.ctrlHDeletes
+	settings.RefreshRate = m.refreshRate
+	return settings
+}
 
-	if err := UpdateSettings(settings); err != nil {
+func saveAndGoBack(m *StartScreenModel) tea.Cmd {
+	if err := UpdateSettings(m.menuSettings()); err != nil {
 		devlog.Log("Settings: Error updating settings: %v", err)
 	}
 
@@ -699,11 +1063,8 @@ func cycleCursor(m *StartScreenModel) tea.Cmd {
 }
 
 func cycleGameMode(m *StartScreenModel) tea.Cmd {
-	if m.gameMode == "normal" {
-		m.gameMode = "simple"
-	} else {
-		m.gameMode = "normal"
-	}
+	names := GameModeNames()
+	m.gameMode = names[(slices.Index(names, m.gameMode)+1)%len(names)]
 
 	return nil
 }
@@ -713,6 +1074,11 @@ func toggleNumbers(m *StartScreenModel) tea.Cmd {
 	return nil
 }
 
+func togglePunctuation(m *StartScreenModel) tea.Cmd {
+	m.usePunctuation = !m.usePunctuation
+	return nil
+}
+
 func cycleTextLength(m *StartScreenModel) tea.Cmd {
 	lengths := []string{TextLengthShort, TextLengthMedium, TextLengthLong, TextLengthVeryLong}
 	var currentIndex int
@@ -747,6 +1113,65 @@ func cycleRefreshRate(m *StartScreenModel) tea.Cmd {
 	return nil
 }
 
+func cycleLanguage(m *StartScreenModel) tea.Cmd {
+	languages := ListAvailableLanguages()
+	if len(languages) == 0 {
+		return nil
+	}
+
+	currentIndex := -1
+	for i, code := range languages {
+		if code == m.language {
+			currentIndex = i
+			break
+		}
+	}
+
+	currentIndex = (currentIndex + 1) % len(languages)
+	m.language = languages[currentIndex]
+
+	return nil
+}
+
+func cycleKeyboardLayout(m *StartScreenModel) tea.Cmd {
+	layouts := ListAvailableLayouts()
+
+	currentIndex := -1
+	for i, name := range layouts {
+		if name == m.keyboardLayout {
+			currentIndex = i
+			break
+		}
+	}
+
+	currentIndex = (currentIndex + 1) % len(layouts)
+	m.keyboardLayout = layouts[currentIndex]
+
+	return nil
+}
+
+func cycleErrorPolicy(m *StartScreenModel) tea.Cmd {
+	index := slices.Index(ErrorPolicies, ParseErrorPolicy(m.errorPolicy))
+	m.errorPolicy = string(ErrorPolicies[(index+1)%len(ErrorPolicies)])
+	return nil
+}
+
+func cycleBackspacePolicy(m *StartScreenModel) tea.Cmd {
+	index := slices.Index(BackspacePolicies, ParseBackspacePolicy(m.backspace))
+	m.backspace = string(BackspacePolicies[(index+1)%len(BackspacePolicies)])
+	return nil
+}
+
+func toggleKeyboard(m *StartScreenModel) tea.Cmd {
+	m.showKeyboard = !m.showKeyboard
+	return nil
+}
+
+func toggleCtrlH(m *StartScreenModel) tea.Cmd {
+	m.ctrlHDeletes = !m.ctrlHDeletes
+	return nil
+}
+
 func RunStartScreen() {
 	ShowWelcomeScreen()
 
@@ -759,22 +1184,23 @@ func RunStartScreen() {
 	}
 
 	if m, ok := model.(*StartScreenModel); ok {
-		UpdateSettings(UserSettings{
-			ThemeName:      m.selectedTheme,
-			CursorType:     m.cursorType,
-			GameMode:       m.gameMode,
-			UseNumbers:     m.useNumbers,
-			TextLength:     m.textLength,
-			RefreshRate:    m.refreshRate,
-			HasSeenWelcome: CurrentSettings.HasSeenWelcome,
-		})
+		UpdateSettings(m.menuSettings())
 
 		if m.menuState == MenuMain && m.selectedItem < len(m.mainMenuItems) {
 			item := m.mainMenuItems[m.selectedItem]
 
-			if item.title == "Start Typing" {
+			switch item.title {
+			case "Start Typing":
 				StartLoadingWithOptions(m.cursorType, "")
+			case "Daily":
+				RunDaily()
+			case "Local Duel":
+				RunDuel("", [2]string{})
 			}
 		}
+
+		if m.menuState == MenuLessons && m.lessonToStart >= 0 {
+			RunLesson(m.lessonToStart)
+		}
 	}
 }
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSaveAndGoBack(t *testing.T) {
+	testConfigDir(t)
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+
+	// set in settings.json by hand, the menu doesn't show them
+	CurrentSettings = DefaultSettings
+	CurrentSettings.Pipelines = map[string][]string{GameModeNormal: {"lowercase"}}
+	CurrentSettings.WordDeleteKeys = []string{"ctrl+u"}
+	CurrentSettings.ASCIIQuotes = true
+	CurrentSettings.TimeLimit = 45
+	CurrentSettings.HasSeenWelcome = true
+
+	m := NewStartScreenModel()
+	m.gameMode = GameModeWords
+	m.useNumbers = !CurrentSettings.UseNumbers
+	m.backspace = string(BackspaceOff)
+	toggleCtrlH(m)
+	saveAndGoBack(m)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"game mode", CurrentSettings.GameMode, GameModeWords},
+		{"numbers", CurrentSettings.UseNumbers, !DefaultSettings.UseNumbers},
+		{"backspace policy", CurrentSettings.BackspacePolicy, string(BackspaceOff)},
+		{"ctrl+h", CurrentSettings.CtrlHDeletesWord, true},
+		{"pipelines", slices.Equal(CurrentSettings.Pipelines[GameModeNormal], []string{"lowercase"}), true},
+		{"word delete keys", slices.Equal(CurrentSettings.WordDeleteKeys, []string{"ctrl+u"}), true},
+		{"ascii quotes", CurrentSettings.ASCIIQuotes, true},
+		{"time limit", CurrentSettings.TimeLimit, 45},
+		{"welcome", CurrentSettings.HasSeenWelcome, true},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
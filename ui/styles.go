@@ -30,6 +30,7 @@ var (
 	TextContainerStyle         lipgloss.Style         // Text container style
 	BlockCursorStyle           lipgloss.Style         // Block cursor style
 	UnderlineCursorStyle       lipgloss.Style         // Underline cursor style
+	RacerCaretStyle            lipgloss.Style         // Other racers' caret style
 	SettingsListStyle          lipgloss.Style         // Settings list style
 	SettingsDetailsStyle       lipgloss.Style         // Settings details style
 	SettingsTitleStyle         lipgloss.Style         // Settings title style
@@ -92,6 +93,10 @@ func UpdateStyles() {
 		Foreground(GetColor("cursor_underline")).
 		Underline(true)
 
+	RacerCaretStyle = lipgloss.NewStyle().
+		Foreground(GetColor("timer")).
+		Underline(true)
+
 	SettingsListStyle = lipgloss.NewStyle().
 		Width(MaxWidth/3 - 4).
 		MarginLeft(2).
@@ -5,31 +5,42 @@ import (
 	"time"
 
 	devlog "github.com/prime-run/go-typer/log"
+	"golang.org/x/text/unicode/norm"
 )
 
+// Caret is the position of another racer in the text.
+type Caret struct {
+	Word   int // index into the text's words, spaces included
+	Letter int // letters typed into that word
+}
+
 type Text struct {
 	words      []*Word
 	cursorPos  int
 	showCursor bool
 	cursorType CursorType
 	sourceText string
+	direction  TextDirection
+
+	errorPolicy     ErrorPolicy
+	backspacePolicy BackspacePolicy
 }
 
 func NewText(text string) *Text {
 
 	estimatedWordCount := len(text)/6 + 1
 	words := make([]*Word, 0, estimatedWordCount)
-	var currentWord []rune
+	var currentWord []string
 
-	for _, r := range text {
-		if r == ' ' {
+	for _, g := range graphemes(text) {
+		if g == " " {
 			if len(currentWord) > 0 {
 				words = append(words, NewWord(currentWord))
-				currentWord = make([]rune, 0, 8)
+				currentWord = make([]string, 0, 8)
 			}
-			words = append(words, NewWord([]rune{' '}))
+			words = append(words, NewWord([]string{" "}))
 		} else {
-			currentWord = append(currentWord, r)
+			currentWord = append(currentWord, g)
 		}
 	}
 
@@ -43,15 +54,87 @@ func NewText(text string) *Text {
 		showCursor: true,
 		cursorType: UnderlineCursor,
 		sourceText: text,
+
+		errorPolicy:     ErrorPolicyFree,
+		backspacePolicy: BackspaceFree,
 	}
 
 	if len(t.words) > 0 {
 		t.words[0].SetActive(true)
 	}
 
+	t.SetDirection(DetectDirection(text))
+
 	return t
 }
 
+// SetDirection sets the paragraph direction. Unless the terminal does its
+// own bidi reordering, words of right to left passages are drawn reversed.
+func (t *Text) SetDirection(direction TextDirection) {
+	t.direction = direction
+	reorder := direction == DirectionRTL && !CurrentSettings.TerminalBidi
+	for _, word := range t.words {
+		word.SetRTL(reorder && !word.IsSpace() && !isLTRWord(word.Target()))
+	}
+}
+
+func (t *Text) Direction() TextDirection {
+	return t.direction
+}
+
+// SetErrorPolicy sets what happens to wrong keys.
+func (t *Text) SetErrorPolicy(policy ErrorPolicy) {
+	t.errorPolicy = policy
+}
+
+func (t *Text) ErrorPolicy() ErrorPolicy {
+	return t.errorPolicy
+}
+
+// SetBackspacePolicy sets how far backspace can walk back.
+func (t *Text) SetBackspacePolicy(policy BackspacePolicy) {
+	t.backspacePolicy = policy
+}
+
+func (t *Text) BackspacePolicy() BackspacePolicy {
+	return t.backspacePolicy
+}
+
+// Caret returns the position of the player's caret.
+func (t *Text) Caret() Caret {
+	caret := Caret{Word: t.cursorPos}
+	if word := t.CurrentWord(); word != nil {
+		caret.Letter = len(word.typed)
+	}
+	return caret
+}
+
+// Progress returns the share of the text the caret has passed, 0 to 1.
+func (t *Text) Progress() float64 {
+	if len(t.words) == 0 || t.Finished() {
+		return 1
+	}
+	return float64(t.cursorPos) / float64(len(t.words))
+}
+
+// SetCarets shows other racers' carets in the text. A caret at the end of a
+// word waits on the space after it.
+func (t *Text) SetCarets(carets []Caret) {
+	letters := make(map[int][]int, len(carets))
+	for _, caret := range carets {
+		if caret.Word < 0 || caret.Word >= len(t.words) {
+			continue
+		}
+		if word := t.words[caret.Word]; caret.Letter >= len(word.target) && caret.Word+1 < len(t.words) {
+			caret = Caret{Word: caret.Word + 1}
+		}
+		letters[caret.Word] = append(letters[caret.Word], caret.Letter)
+	}
+	for i, word := range t.words {
+		word.SetCarets(letters[i])
+	}
+}
+
 func (t *Text) CurrentWord() *Word {
 	if t.cursorPos >= len(t.words) {
 		return nil
@@ -59,28 +142,47 @@ func (t *Text) CurrentWord() *Word {
 	return t.words[t.cursorPos]
 }
 
-func (t *Text) Type(r rune) {
+// NextGrapheme returns what the player is expected to type next, a space
+// once the current word is fully typed.
+func (t *Text) NextGrapheme() string {
+	currentWord := t.CurrentWord()
+	if currentWord == nil {
+		return ""
+	}
+	if currentWord.IsSpace() || len(currentWord.typed) >= len(currentWord.target) {
+		return " "
+	}
+	return currentWord.target[len(currentWord.typed)]
+}
+
+// Type feeds a single grapheme cluster into the current word.
+func (t *Text) Type(g string) {
 	if t.cursorPos >= len(t.words) {
 		return
 	}
 
 	currentWord := t.words[t.cursorPos]
 
+	if !t.accepts(currentWord, g) {
+		t.refuse(currentWord)
+		return
+	}
+
 	if currentWord.IsSpace() {
-		if r == ' ' {
-			currentWord.Type(r)
+		if g == " " {
+			currentWord.Type(g)
 			if t.cursorPos < len(t.words)-1 {
 				currentWord.SetActive(false)
 				t.cursorPos++
 				t.words[t.cursorPos].SetActive(true)
 			}
 		} else {
-			currentWord.Type(r)
+			currentWord.Type(g)
 		}
 		return
 	}
 
-	if r == ' ' {
+	if g == " " {
 		if !currentWord.HasStarted() {
 			return
 		}
@@ -104,31 +206,76 @@ func (t *Text) Type(r rune) {
 			}
 		}
 	} else {
-		currentWord.Type(r)
+		// NOTE: the caret stays on a fully typed word, more letters become
+		// extra letters and only space moves on to the next word
+		currentWord.Type(g)
+	}
+}
 
-		if currentWord.IsComplete() {
-			if t.cursorPos < len(t.words)-1 {
-				nextWord := t.words[t.cursorPos+1]
-				currentWord.SetActive(false)
-				t.cursorPos++
-				nextWord.SetActive(true)
-			} else {
-				currentWord.SetActive(false)
-				if !currentWord.IsComplete() {
-					currentWord.Skip()
-				}
-			}
+// accepts reports whether the error policy lets g through.
+func (t *Text) accepts(word *Word, g string) bool {
+	switch t.errorPolicy {
+	case ErrorPolicyStopOnLetter:
+		// NOTE: a space before the word is started is ignored, not a mistake
+		return g == t.NextGrapheme() || (g == " " && !word.IsSpace() && !word.HasStarted())
+	case ErrorPolicyStopOnWord:
+		return g != " " || word.IsSpace() || !word.HasStarted() || t.canLeave(word)
+	}
+	return true
+}
+
+// refuse records a key the error policy didn't let through. Only wrong
+// letters count, a refused space just waits for the word to be fixed. Wrong
+// keys on a space count against the word before it.
+func (t *Text) refuse(word *Word) {
+	if t.errorPolicy != ErrorPolicyStopOnLetter {
+		return
+	}
+	if word.IsSpace() && t.cursorPos > 0 {
+		word = t.words[t.cursorPos-1]
+	}
+	word.Mistake()
+}
+
+// canLeave reports whether the caret may move past a fully typed word.
+// NOTE: without backspace a wrong word can't be fixed, so it is let go
+func (t *Text) canLeave(word *Word) bool {
+	if t.errorPolicy == ErrorPolicyStopOnWord && t.backspacePolicy != BackspaceOff {
+		return word.state == Perfect
+	}
+	return true
+}
+
+// TypeRunes feeds a burst of runes (IME commits, compose sequences or fast
+// typing coalesced by the terminal) into the text one grapheme cluster at a
+// time. The burst is NFC normalized first and runes that continue the last
+// typed cluster (dead key marks, emoji modifiers, ZWJ) are merged into it,
+// so they compare equal to the target.
+func (t *Text) TypeRunes(runes []rune) {
+	for _, g := range graphemes(norm.NFC.String(string(runes))) {
+		if t.extend(g) {
+			continue
 		}
+		t.Type(g)
+	}
+}
+
+// extend merges g into the last typed grapheme cluster if it continues it.
+func (t *Text) extend(g string) bool {
+	currentWord := t.CurrentWord()
+	if currentWord == nil || !currentWord.HasStarted() {
+		return false
 	}
+	return currentWord.Extend(g)
 }
 
 func (t *Text) Backspace() {
-	if t.cursorPos >= len(t.words) {
+	if t.cursorPos >= len(t.words) || t.backspacePolicy == BackspaceOff {
 		return
 	}
 
 	currentWord := t.words[t.cursorPos]
-	if !currentWord.Backspace() && t.cursorPos > 0 {
+	if !currentWord.Backspace() && t.cursorPos > 0 && t.backspacePolicy != BackspaceWord {
 		currentWord.SetActive(false)
 		t.cursorPos--
 		currentWord = t.words[t.cursorPos]
@@ -145,6 +292,31 @@ func (t *Text) Backspace() {
 	}
 }
 
+// DeleteWord clears the current word, or the previous one if nothing was
+// typed into the current word yet. The backspace policy applies.
+func (t *Text) DeleteWord() {
+	if t.cursorPos >= len(t.words) || t.backspacePolicy == BackspaceOff {
+		return
+	}
+
+	currentWord := t.words[t.cursorPos]
+	if currentWord.Clear() || t.backspacePolicy == BackspaceWord {
+		return
+	}
+
+	// NOTE: walk back over the space to the previous word
+	for t.cursorPos > 0 {
+		currentWord.SetActive(false)
+		t.cursorPos--
+		currentWord = t.words[t.cursorPos]
+		currentWord.SetActive(true)
+		currentWord.Clear()
+		if !currentWord.IsSpace() {
+			return
+		}
+	}
+}
+
 func (t *Text) Render() string {
 	startTime := time.Now()
 	devlog.Log("Text: Render started")
@@ -162,8 +334,12 @@ func (t *Text) Render() string {
 		showCursor = true
 	}
 
-	for _, word := range t.words {
-		result.WriteString(word.Render(showCursor))
+	if t.direction == DirectionRTL && !CurrentSettings.TerminalBidi {
+		result.WriteString(t.renderRTL(showCursor))
+	} else {
+		for i := range t.words {
+			result.WriteString(t.renderWord(i, showCursor))
+		}
 	}
 
 	rendered := TextContainerStyle.Render(result.String())
@@ -174,6 +350,18 @@ func (t *Text) Render() string {
 	return rendered
 }
 
+// renderWord renders the word at index i. Once the current word is fully
+// typed the caret waits on the space after it.
+func (t *Text) renderWord(i int, showCursor bool) string {
+	word := t.words[i]
+	if showCursor && i == t.cursorPos+1 && word.IsSpace() && !word.HasStarted() {
+		if current := t.words[t.cursorPos]; !current.IsSpace() && current.IsComplete() {
+			return word.cursor.Render(" ")
+		}
+	}
+	return word.Render(showCursor)
+}
+
 func (t *Text) Update() {
 	t.showCursor = true
 }
@@ -194,6 +382,15 @@ func (t *Text) IsComplete() bool {
 	return true
 }
 
+// Finished reports whether the last word of the text has been typed.
+func (t *Text) Finished() bool {
+	if len(t.words) == 0 {
+		return true
+	}
+	last := t.words[t.cursorPos]
+	return t.cursorPos == len(t.words)-1 && last.IsComplete() && t.canLeave(last)
+}
+
 func (t *Text) GetCursorPos() int {
 	return t.cursorPos
 }
@@ -204,10 +401,33 @@ func (t *Text) Stats() (total, correct, errors int) {
 			continue
 		}
 
-		switch word.state {
-		case Perfect:
+		switch {
+		case word.Correct():
+			correct++
+		case word.state == Error || word.mistakes > 0:
+			errors++
+		}
+		total++
+	}
+	return
+}
+
+// TypedStats is like Stats but only counts the words the caret has reached,
+// for games that end before the text does. The word being typed is left out
+// while it's right so far.
+func (t *Text) TypedStats() (total, correct, errors int) {
+	for i, word := range t.words {
+		if word.IsSpace() || (i > t.cursorPos) || (i == t.cursorPos && !word.HasStarted()) {
+			continue
+		}
+		if i == t.cursorPos && word.state == Imperfect && word.mistakes == 0 {
+			continue
+		}
+
+		switch {
+		case word.Correct():
 			correct++
-		case Error:
+		case word.state != Untyped:
 			errors++
 		}
 		total++
@@ -215,6 +435,15 @@ func (t *Text) Stats() (total, correct, errors int) {
 	return
 }
 
+// ExtraLetters counts the letters typed past the end of words.
+func (t *Text) ExtraLetters() int {
+	extra := 0
+	for _, word := range t.words {
+		extra += word.Extra()
+	}
+	return extra
+}
+
 func (t *Text) GetText() string {
 	if t.sourceText != "" {
 		return t.sourceText
@@ -225,7 +454,7 @@ func (t *Text) GetText() string {
 
 	for _, word := range t.words {
 		if !word.IsSpace() {
-			builder.WriteString(string(word.target))
+			builder.WriteString(word.Target())
 		} else {
 			builder.WriteRune(' ')
 		}
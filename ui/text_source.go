@@ -78,71 +78,7 @@ func (s *ZenQuotesSource) FetchText() (string, error) {
 }
 
 func (s *ZenQuotesSource) FormatText(text string) string {
-	if CurrentSettings.GameMode == GameModeSimple {
-		var builder strings.Builder
-		builder.Grow(len(text))
-
-		for _, r := range text {
-			if r >= 'A' && r <= 'Z' {
-				builder.WriteRune(r + 32) // Lowercase (faster than unicode functions)
-			} else if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == ' ' {
-				builder.WriteRune(r)
-			} else if r == '.' || r == ',' || r == ';' || r == ':' || r == '!' || r == '?' {
-			} else {
-				builder.WriteRune(' ')
-			}
-		}
-
-		processed := builder.String()
-		words := strings.Fields(processed)
-
-		if len(words) > 100 {
-			words = words[:100]
-		}
-
-		var finalBuilder strings.Builder
-		finalBuilder.Grow(len(processed))
-
-		for i, word := range words {
-			finalBuilder.WriteString(word)
-			if i < len(words)-1 {
-				finalBuilder.WriteRune(' ')
-			}
-		}
-
-		return finalBuilder.String()
-	}
-
-	var builder strings.Builder
-	builder.Grow(len(text))
-
-	for _, r := range text {
-		if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') ||
-			r == ' ' || r == '.' || r == ',' || r == ';' || r == ':' || r == '!' || r == '?' {
-			builder.WriteRune(r)
-		} else {
-			builder.WriteRune(' ')
-		}
-	}
-
-	processed := builder.String()
-	words := strings.Fields(processed)
-
-	if len(words) > 100 {
-		words = words[:100]
-	}
-
-	var finalBuilder strings.Builder
-	finalBuilder.Grow(len(processed))
-
-	for i, word := range words {
-		finalBuilder.WriteString(word)
-		if i < len(words)-1 {
-			finalBuilder.WriteRune(' ')
-		}
-	}
-
-	return finalBuilder.String()
+	return formatSourceText(text)
 }
 
 type BibleSource struct {
@@ -189,78 +125,116 @@ func (s *BibleSource) FetchText() (string, error) {
 }
 
 func (s *BibleSource) FormatText(text string) string {
-	if CurrentSettings.GameMode == GameModeSimple {
-		var builder strings.Builder
-		builder.Grow(len(text))
-
-		for _, r := range text {
-			if r >= 'A' && r <= 'Z' {
-				builder.WriteRune(r + 32)
-			} else if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == ' ' {
-				builder.WriteRune(r)
-			} else if r == '.' || r == ',' || r == ';' || r == ':' || r == '!' || r == '?' {
-			} else {
-				builder.WriteRune(' ')
-			}
-		}
-
-		processed := builder.String()
-		words := strings.Fields(processed)
-
-		if len(words) > 100 {
-			words = words[:100]
-		}
+	return formatSourceText(text)
+}
 
-		var finalBuilder strings.Builder
-		finalBuilder.Grow(len(processed))
+// LanguagePackSource picks passages from an embedded or user provided
+// language pack, no network needed.
+type LanguagePackSource struct {
+	Pack *LanguagePack
+}
 
-		for i, word := range words {
-			finalBuilder.WriteString(word)
-			if i < len(words)-1 {
-				finalBuilder.WriteRune(' ')
-			}
-		}
+func NewLanguagePackSource(pack *LanguagePack) *LanguagePackSource {
+	return &LanguagePackSource{
+		Pack: pack,
+	}
+}
 
-		return finalBuilder.String()
+func (s *LanguagePackSource) FetchText() (string, error) {
+	devlog.Log("TextSource: Picking passage from language pack %s", s.Pack.Code)
+	text := s.Pack.RandomQuote()
+	if text == "" {
+		return "", fmt.Errorf("language pack %s is empty", s.Pack.Code)
 	}
+	return text, nil
+}
 
-	var builder strings.Builder
-	builder.Grow(len(text))
+func (s *LanguagePackSource) FormatText(text string) string {
+	return formatSourceText(text)
+}
 
-	for _, r := range text {
-		if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') ||
-			r == ' ' || r == '.' || r == ',' || r == ';' || r == ':' || r == '!' || r == '?' {
-			builder.WriteRune(r)
-		} else {
-			builder.WriteRune(' ')
-		}
+// PipelineSpecs lists the transforms text goes through for the settings:
+// unicode cleanup, then the game mode's transforms.
+func PipelineSpecs(settings UserSettings) []string {
+	specs := []string{"nfc"}
+	if settings.ASCIIQuotes {
+		specs = append(specs, "ascii_quotes")
+	}
+	if settings.StripDiacritics {
+		specs = append(specs, "ascii_fold")
 	}
 
-	processed := builder.String()
-	words := strings.Fields(processed)
+	return append(specs, LookupGameMode(settings.GameMode).Pipeline(settings)...)
+}
 
-	if len(words) > 100 {
-		words = words[:100]
+// TextPipeline builds the transform pipeline for the settings. A broken
+// pipeline in the config falls back to the built-in one.
+func TextPipeline(settings UserSettings) utils.Pipeline {
+	pipeline, err := utils.ParsePipeline(PipelineSpecs(settings))
+	if err == nil {
+		return pipeline
 	}
 
-	var finalBuilder strings.Builder
-	finalBuilder.Grow(len(processed))
+	devlog.Log("TextSource: Invalid text pipeline, using the default one: %v", err)
+	settings.Pipelines = nil
+	settings.NumberRate = DefaultSettings.NumberRate
+	settings.PunctuationRate = DefaultSettings.PunctuationRate
+	pipeline, _ = utils.ParsePipeline(PipelineSpecs(settings))
+	return pipeline
+}
 
-	for i, word := range words {
-		finalBuilder.WriteString(word)
-		if i < len(words)-1 {
-			finalBuilder.WriteRune(' ')
-		}
-	}
+// formatSourceText runs text from any source through the pipeline of the
+// current settings.
+func formatSourceText(text string) string {
+	return TextPipeline(CurrentSettings).Apply(text)
+}
 
-	return finalBuilder.String()
+// SeedText seeds the text generator and puts the settings that shape the
+// text (injection, transforms, passage length) back to their defaults for
+// this run, so the same seed, game mode, language and word count give the
+// same passages on any machine. Nothing is saved.
+func SeedText(seed int64) {
+	utils.SeedRandom(seed)
+
+	CurrentSettings.TextLength = DefaultSettings.TextLength
+	CurrentSettings.UseNumbers = DefaultSettings.UseNumbers
+	CurrentSettings.UsePunctuation = DefaultSettings.UsePunctuation
+	CurrentSettings.NumberRate = DefaultSettings.NumberRate
+	CurrentSettings.PunctuationRate = DefaultSettings.PunctuationRate
+	CurrentSettings.ASCIIQuotes = DefaultSettings.ASCIIQuotes
+	CurrentSettings.StripDiacritics = DefaultSettings.StripDiacritics
+	CurrentSettings.Pipelines = nil
 }
 
-func GetRandomText() string {
+// GetRandomText returns a quote run through the pipeline of the settings.
+func GetRandomText(settings UserSettings) string {
+	return TextPipeline(settings).Apply(randomQuote(settings))
+}
+
+// randomQuote picks an unformatted quote: from the language pack of the
+// settings, the online sources or the built-in English pack.
+func randomQuote(settings UserSettings) string {
 	var source TextSource
 	var err error
 	var text string
 
+	// NOTE: the online sources are english only, other languages come from packs
+	if lang := settings.Language; lang != "" && lang != DefaultLanguage {
+		text, err := fetchFromLanguagePack(lang)
+		if err == nil {
+			return text
+		}
+		devlog.Log("TextSource: Language pack %s failed: %v", lang, err)
+	}
+
+	// NOTE: the online sources can't be seeded, a seeded game stays offline
+	// so the same seed gives the same text everywhere
+	if utils.RandomSeeded() {
+		if text, err := fetchFromLanguagePack(DefaultLanguage); err == nil {
+			return text
+		}
+	}
+
 	for i := range 2 {
 		switch i {
 		case 0:
@@ -274,11 +248,25 @@ func GetRandomText() string {
 		text, err = source.FetchText()
 		if err == nil {
 			devlog.Log("TextSource: Successfully fetched text: %s", text)
-			return source.FormatText(text)
+			return text
 		}
 		devlog.Log("TextSource: Failed to fetch from source %d: %v", i, err)
 	}
 
+	if text, err := fetchFromLanguagePack(DefaultLanguage); err == nil {
+		devlog.Log("TextSource: All online sources failed, using the %s language pack", DefaultLanguage)
+		return text
+	}
+
 	devlog.Log("TextSource: All sources failed, using default text")
 	return "The quick brown fox jumps over the lazy dog."
 }
+
+func fetchFromLanguagePack(code string) (string, error) {
+	pack, err := LoadLanguagePack(code)
+	if err != nil {
+		return "", err
+	}
+
+	return NewLanguagePackSource(pack).FetchText()
+}
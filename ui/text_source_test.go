@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSeedText(t *testing.T) {
+	testConfigDir(t)
+
+	// a user pack that a seeded game must not pick up
+	dir := GetLanguagesDirPath()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	override := "code: en\nwords: [override]\nquotes:\n  - text: Override quote.\n"
+	if err := os.WriteFile(filepath.Join(dir, DefaultLanguage+YMLSuffix), []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := CurrentSettings
+	t.Cleanup(func() { CurrentSettings = saved })
+
+	// tweaked stands for another player's settings, which the seed overrides
+	tweaked := DefaultSettings
+	tweaked.UseNumbers = !DefaultSettings.UseNumbers
+	tweaked.UsePunctuation = !DefaultSettings.UsePunctuation
+	tweaked.StripDiacritics = !DefaultSettings.StripDiacritics
+	tweaked.Pipelines = map[string][]string{GameModeWords: {"lowercase", "max_words:2"}}
+
+	play := func(settings UserSettings, mode string, seed int64) string {
+		CurrentSettings = settings
+		CurrentSettings.GameMode = mode
+		SeedText(seed)
+		return LookupGameMode(mode).GenerateText(CurrentSettings)
+	}
+
+	for _, mode := range []string{GameModeWords, GameModeTimed, GameModeZen, GameModeNormal, GameModeSimple} {
+		t.Run(mode, func(t *testing.T) {
+			text := play(DefaultSettings, mode, 42)
+			if text == "" {
+				t.Fatal("no text")
+			}
+			if strings.Contains(strings.ToLower(text), "override") {
+				t.Errorf("seeded text %q comes from the user pack", text)
+			}
+			if again := play(DefaultSettings, mode, 42); again != text {
+				t.Errorf("the same seed gave %q then %q", text, again)
+			}
+			if other := play(tweaked, mode, 42); other != text {
+				t.Errorf("other settings changed the seeded text from %q to %q", text, other)
+			}
+		})
+	}
+
+	CurrentSettings = tweaked
+	SeedText(0)
+	if CurrentSettings.UseNumbers != DefaultSettings.UseNumbers ||
+		CurrentSettings.UsePunctuation != DefaultSettings.UsePunctuation ||
+		CurrentSettings.StripDiacritics != DefaultSettings.StripDiacritics ||
+		CurrentSettings.Pipelines != nil {
+		t.Errorf("SeedText kept the text settings %+v", CurrentSettings)
+	}
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+// typeKeys feeds key events into text like the game does: every rune is a
+// key, except '\b' for backspace and '\x17' (ctrl+w) for a word delete.
+func typeKeys(text *Text, keys string) {
+	for _, r := range keys {
+		switch r {
+		case '\b':
+			text.Backspace()
+		case '\x17':
+			text.DeleteWord()
+		default:
+			text.TypeRunes([]rune{r})
+		}
+	}
+}
+
+func TestTypeRunes(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		events      []string // one TypeRunes call each
+		wantTyped   []string // clusters typed into the first word
+		wantCorrect int
+	}{
+		{"precomposed letter", "café", []string{"c", "a", "f", "é"}, []string{"c", "a", "f", "é"}, 1},
+		{"dead key accent", "café", []string{"c", "a", "f", "e", "\u0301"}, []string{"c", "a", "f", "é"}, 1},
+		{"decomposed burst", "café", []string{"cafe\u0301"}, []string{"c", "a", "f", "é"}, 1},
+		{"accent on a wrong letter", "é", []string{"a", "\u0301"}, []string{"á"}, 0},
+		{"emoji modifier", "👍🏽", []string{"👍", "🏽"}, []string{"👍🏽"}, 1},
+		{"flag", "🇩🇪", []string{"🇩", "🇪"}, []string{"🇩🇪"}, 1},
+		{"zwj sequence", "👩\u200d💻", []string{"👩", "\u200d", "💻"}, []string{"👩\u200d💻"}, 1},
+		{"separate letters", "ab", []string{"a", "b"}, []string{"a", "b"}, 1},
+		{"burst across words", "hi you", []string{"hi you"}, []string{"h", "i"}, 2},
+		{"hangul", "한국", []string{"한", "국"}, []string{"한", "국"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := NewText(tt.text)
+			for _, event := range tt.events {
+				text.TypeRunes([]rune(event))
+			}
+
+			if got := text.words[0].typed; !slices.Equal(got, tt.wantTyped) {
+				t.Errorf("typed %q, want %q", got, tt.wantTyped)
+			}
+			if _, correct, _ := text.Stats(); correct != tt.wantCorrect {
+				t.Errorf("%d correct words, want %d", correct, tt.wantCorrect)
+			}
+		})
+	}
+}
+
+func TestWordExtend(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		typed     []string
+		s         string
+		want      bool
+		wantTyped []string
+	}{
+		{"combining mark", "é", []string{"e"}, "\u0301", true, []string{"é"}},
+		{"skin tone", "👍🏽", []string{"👍"}, "🏽", true, []string{"👍🏽"}},
+		{"new letter", "ab", []string{"a"}, "b", false, []string{"a"}},
+		{"nothing typed", "é", nil, "\u0301", false, nil},
+		{"skipped letter", "é", []string{""}, "\u0301", false, []string{""}},
+		{"space", " ", []string{" "}, "\u0301", false, []string{" "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			word := NewWord(graphemes(tt.target))
+			word.typed = append(word.typed, tt.typed...)
+
+			if got := word.Extend(tt.s); got != tt.want {
+				t.Errorf("Extend(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+			if !slices.Equal(word.typed, tt.wantTyped) {
+				t.Errorf("typed %q, want %q", word.typed, tt.wantTyped)
+			}
+		})
+	}
+}
+
+func TestTypedStats(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      ErrorPolicy
+		keys        string
+		wantTotal   int
+		wantCorrect int
+		wantErrors  int
+	}{
+		{"nothing typed", ErrorPolicyFree, "", 0, 0, 0},
+		{"right so far", ErrorPolicyFree, "one tw", 1, 1, 0},
+		{"first letter", ErrorPolicyFree, "one t", 1, 1, 0},
+		{"finished word", ErrorPolicyFree, "one two", 2, 2, 0},
+		{"typo in the current word", ErrorPolicyFree, "one tx", 2, 1, 1},
+		{"typo fixed", ErrorPolicyFree, "one tx\bw", 1, 1, 0},
+		{"refused typo", ErrorPolicyStopOnLetter, "one txw", 2, 1, 1},
+		{"word left unfinished", ErrorPolicyFree, "on t", 1, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := NewText("one two three")
+			text.SetErrorPolicy(tt.policy)
+			typeKeys(text, tt.keys)
+
+			total, correct, errors := text.TypedStats()
+			if total != tt.wantTotal || correct != tt.wantCorrect || errors != tt.wantErrors {
+				t.Errorf("TypedStats() = %d, %d, %d, want %d, %d, %d", total, correct, errors, tt.wantTotal, tt.wantCorrect, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"", []string{}},
+		{"abc", []string{"a", "b", "c"}},
+		{"e\u0301x", []string{"e\u0301", "x"}},
+		{"🇩🇪!", []string{"🇩🇪", "!"}},
+		{"a b", []string{"a", " ", "b"}},
+	}
+
+	for _, tt := range tests {
+		if got := graphemes(tt.s); !slices.Equal(got, tt.want) {
+			t.Errorf("graphemes(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prime-run/go-typer/utils"
+)
+
+const (
+	verifyTolerance   = 0.5                   // WPM or accuracy points a claimed score may be off by
+	verifyMinInterval = 10 * time.Millisecond // keys closer than this are too fast to be typed
+	verifyMaxBurst    = 8                     // intervals in a row that may be under verifyMinInterval
+	verifyMaxRunes    = 4                     // letters a single key event may carry (IME commits, compose)
+	verifyMaxWPM      = 250                   // above the fastest sustained typing on record
+	verifyClockSlack  = time.Second           // how far the game clock may trail the last keystroke
+)
+
+// ResultLog is a finished game with everything needed to replay it.
+type ResultLog struct {
+	Text            string        `json:"text"`
+	Mode            string        `json:"mode"`
+	WPM             float64       `json:"wpm"`
+	Accuracy        float64       `json:"accuracy"`
+	Elapsed         time.Duration `json:"elapsed"`              // game time the score was computed over
+	TimeLimit       time.Duration `json:"time_limit,omitempty"` // limit of a timed game
+	ErrorPolicy     string        `json:"error_policy"`
+	BackspacePolicy string        `json:"backspace_policy"`
+	Keystrokes      []Keystroke   `json:"keystrokes"`
+}
+
+// Verification is the outcome of replaying a ResultLog.
+type Verification struct {
+	Result GameResult // score recomputed from the keystrokes
+	Issues []string   // why the result can't be trusted, empty if it can
+}
+
+// OK reports whether the result checked out.
+func (v Verification) OK() bool {
+	return len(v.Issues) == 0
+}
+
+// Verify replays the keystroke log of a result through the typing engine,
+// recomputes its score and flags results that don't match their log or
+// couldn't have been typed by hand.
+func Verify(result ResultLog) Verification {
+	var v Verification
+	issuef := func(format string, args ...any) {
+		v.Issues = append(v.Issues, fmt.Sprintf(format, args...))
+	}
+
+	if len(result.Keystrokes) == 0 {
+		issuef("the result has no keystroke log")
+		return v
+	}
+
+	mode := replayMode(result.Mode)
+	text := NewText(result.Text)
+	text.SetErrorPolicy(ParseErrorPolicy(result.ErrorPolicy))
+	text.SetBackspacePolicy(ParseBackspacePolicy(result.BackspacePolicy))
+
+	var last time.Duration
+	backwards, pasted, burst, longestBurst := 0, 0, 0, 0
+	for i, keystroke := range result.Keystrokes {
+		switch keystroke.Action {
+		case ActionType:
+			runes := []rune(keystroke.Text)
+			if len(runes) > verifyMaxRunes {
+				pasted++
+			}
+			text.TypeRunes(runes)
+		case ActionBackspace:
+			text.Backspace()
+		case ActionDeleteWord:
+			text.DeleteWord()
+		default:
+			issuef("keystroke %d has an unknown action %q", i+1, keystroke.Action)
+		}
+
+		if i > 0 {
+			switch interval := keystroke.At - last; {
+			case interval < 0:
+				backwards++
+			case interval < verifyMinInterval:
+				burst++
+				longestBurst = max(longestBurst, burst)
+			default:
+				burst = 0
+			}
+		}
+		last = keystroke.At
+	}
+
+	elapsed := result.Elapsed
+	if elapsed <= 0 {
+		elapsed = last
+	}
+	if elapsed+verifyClockSlack < last {
+		issuef("the game lasted %s but its last keystroke came at %s", elapsed, last)
+	}
+
+	state := GameState{Text: text, Started: true, Elapsed: elapsed, TimeLimit: result.TimeLimit}
+	v.Result = mode.Score(state)
+
+	if mode.Name() != GameModeTimed && !text.Finished() {
+		issuef("the keystrokes don't finish the passage")
+	}
+	if math.Abs(result.WPM-v.Result.WPM) > verifyTolerance {
+		issuef("claimed %.1f WPM, the keystrokes give %.1f WPM", result.WPM, v.Result.WPM)
+	}
+	if math.Abs(result.Accuracy-v.Result.Accuracy) > verifyTolerance {
+		issuef("claimed %.1f%% accuracy, the keystrokes give %.1f%%", result.Accuracy, v.Result.Accuracy)
+	}
+	if backwards > 0 {
+		issuef("%d keystrokes are timed before the one they follow", backwards)
+	}
+	if pasted > 0 {
+		issuef("%d keystrokes carry more than %d letters at once, looks pasted", pasted, verifyMaxRunes)
+	}
+	if longestBurst > verifyMaxBurst {
+		issuef("%d keystrokes in a row came less than %s apart", longestBurst+1, verifyMinInterval)
+	}
+	if v.Result.WPM > verifyMaxWPM {
+		issuef("%.0f WPM is beyond human typing speed", v.Result.WPM)
+	}
+
+	return v
+}
+
+// replayMode is the mode a logged game is scored with: the registered mode
+// of that name, or one that isn't offered in the settings, like the daily.
+func replayMode(name string) GameMode {
+	if name == GameModeDaily {
+		return &dailyMode{}
+	}
+	return LookupGameMode(name)
+}
+
+func GetLastResultFilePath() (string, error) {
+	configDir, err := utils.GetAppConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "last_result.json"), nil
+}
+
+// SaveLastResult keeps the result of the last game for `go-typer verify`.
+func SaveLastResult(result ResultLog) error {
+	resultPath, err := GetLastResultFilePath()
+	if err != nil {
+		return fmt.Errorf("failed to get last result file path: %w", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("error marshaling result: %w", err)
+	}
+
+	if err := os.WriteFile(resultPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing result file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadResultLog reads a result saved by SaveLastResult.
+func LoadResultLog(path string) (ResultLog, error) {
+	var result ResultLog
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("error reading result file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("error parsing result file: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// gameLog plays keys (see typeKeys) on passage at one key every interval and
+// logs the game with the score it would have shown. The game lasts until the
+// last key unless elapsed is set.
+func gameLog(mode, passage, keys string, interval, elapsed, limit time.Duration, policy ErrorPolicy) ResultLog {
+	log := ResultLog{
+		Text:            passage,
+		Mode:            mode,
+		TimeLimit:       limit,
+		ErrorPolicy:     string(policy),
+		BackspacePolicy: string(BackspaceFree),
+	}
+
+	text := NewText(passage)
+	text.SetErrorPolicy(policy)
+	for i, r := range keys {
+		keystroke := Keystroke{At: time.Duration(i) * interval, Action: ActionType, Text: string(r)}
+		switch r {
+		case '\b':
+			keystroke.Action, keystroke.Text = ActionBackspace, ""
+		case '\x17':
+			keystroke.Action, keystroke.Text = ActionDeleteWord, ""
+		}
+		typeKeys(text, string(r))
+		log.Keystrokes = append(log.Keystrokes, keystroke)
+	}
+
+	log.Elapsed = elapsed
+	if log.Elapsed == 0 {
+		log.Elapsed = log.Keystrokes[len(log.Keystrokes)-1].At
+	}
+
+	result := LookupGameMode(mode).Score(GameState{Text: text, Started: true, Elapsed: log.Elapsed, TimeLimit: limit})
+	log.WPM, log.Accuracy = result.WPM, result.Accuracy
+	return log
+}
+
+func TestVerify(t *testing.T) {
+	const passage = "the quick brown fox"
+	const every = 200 * time.Millisecond
+	honest := func() ResultLog {
+		return gameLog(GameModeNormal, passage, "the quikc brpwn\b\b\bown fox", every, 0, 0, ErrorPolicyFree)
+	}
+	timed := func() ResultLog {
+		keys := strings.Repeat("the quick brown fox ", 5)
+		return gameLog(GameModeTimed, strings.TrimSpace(keys), keys, every, 20*time.Second, 15*time.Second, ErrorPolicyFree)
+	}
+
+	tests := []struct {
+		name       string
+		log        ResultLog
+		wantIssues []string // parts of the expected issues, none for a clean result
+	}{
+		{"honest game", honest(), nil},
+		{"word deletes", gameLog(GameModeNormal, passage, "the quick bro\x17brown fox", every, 0, 0, ErrorPolicyFree), nil},
+		{"error policy replayed", gameLog(GameModeNormal, passage, "the quixck brown fox", every, 0, 0, ErrorPolicyStopOnLetter), nil},
+		{"timed game with its limit", timed(), nil},
+		{"inflated wpm", func() ResultLog { log := honest(); log.WPM += 10; return log }(), []string{"WPM, the keystrokes give"}},
+		{"inflated accuracy", func() ResultLog { log := honest(); log.Accuracy = 100; return log }(), []string{"accuracy, the keystrokes give"}},
+		{"unfinished passage", func() ResultLog {
+			log := honest()
+			log.Keystrokes = log.Keystrokes[:len(log.Keystrokes)-3]
+			return log
+		}(), []string{"don't finish the passage"}},
+		{"pasted text", func() ResultLog {
+			log := honest()
+			log.Keystrokes = []Keystroke{{At: log.Elapsed, Action: ActionType, Text: passage}}
+			return log
+		}(), []string{"looks pasted"}},
+		{"keys too close together", gameLog(GameModeNormal, passage, "the quick brown fox", time.Millisecond, 0, 0, ErrorPolicyFree), []string{"came less than", "beyond human"}},
+		{"keys out of order", func() ResultLog {
+			log := honest()
+			log.Keystrokes[3].At = 0
+			return log
+		}(), []string{"timed before the one they follow"}},
+		{"clock stopped early", func() ResultLog { log := honest(); log.Elapsed /= 2; return log }(), []string{"but its last keystroke came at"}},
+		{"unknown action", func() ResultLog {
+			log := honest()
+			log.Keystrokes = append(log.Keystrokes, Keystroke{At: log.Elapsed, Action: "paste"})
+			return log
+		}(), []string{`unknown action "paste"`}},
+		{"no keystroke log", func() ResultLog { log := honest(); log.Keystrokes = nil; return log }(), []string{"no keystroke log"}},
+		{"timed game without its limit", func() ResultLog { log := timed(); log.TimeLimit = 0; return log }(), []string{"WPM, the keystrokes give"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Verify(tt.log)
+
+			if len(tt.wantIssues) == 0 && !v.OK() {
+				t.Fatalf("flagged a clean result: %q", v.Issues)
+			}
+			for _, want := range tt.wantIssues {
+				found := false
+				for _, issue := range v.Issues {
+					found = found || strings.Contains(issue, want)
+				}
+				if !found {
+					t.Errorf("issues %q, want one about %q", v.Issues, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	devlog "github.com/prime-run/go-typer/log"
+	"github.com/prime-run/go-typer/race"
+)
+
+// sessionBroadcast streams the games of this session to watchers, nil when
+// not broadcasting.
+var sessionBroadcast *race.Broadcaster
+
+// StartBroadcast streams every game played from now on to watchers
+// connecting to addr.
+func StartBroadcast(addr string) (net.Addr, error) {
+	broadcaster, err := race.NewBroadcaster(addr)
+	if err != nil {
+		return nil, err
+	}
+	sessionBroadcast = broadcaster
+	return broadcaster.Addr(), nil
+}
+
+// StopBroadcast disconnects the watchers.
+func StopBroadcast() {
+	if sessionBroadcast != nil {
+		sessionBroadcast.Close()
+		sessionBroadcast = nil
+	}
+}
+
+// broadcastSession announces the model's passage to the watchers.
+func (m *TypingModel) broadcastSession() {
+	m.announced = true
+	if sessionBroadcast == nil {
+		return
+	}
+	sessionBroadcast.Send(race.Message{
+		Type:            race.MsgSession,
+		Text:            m.text.GetText(),
+		Mode:            m.mode.Name(),
+		ErrorPolicy:     string(m.text.ErrorPolicy()),
+		BackspacePolicy: string(m.text.BackspacePolicy()),
+	})
+}
+
+// broadcastKeystroke streams a keystroke with the caret and live WPM.
+func (m *TypingModel) broadcastKeystroke(keystroke Keystroke) {
+	if sessionBroadcast == nil {
+		return
+	}
+	caret := m.text.Caret()
+	sessionBroadcast.Send(race.Message{
+		Type:     race.MsgKey,
+		Action:   string(keystroke.Action),
+		Text:     keystroke.Text,
+		At:       keystroke.At,
+		Word:     caret.Word,
+		Letter:   caret.Letter,
+		Progress: m.text.Progress(),
+		WPM:      m.mode.Score(m.state()).WPM,
+	})
+}
+
+// broadcastEnd tells the watchers the game is over.
+func (m *TypingModel) broadcastEnd(result GameResult) {
+	if sessionBroadcast == nil {
+		return
+	}
+	sessionBroadcast.Send(race.Message{
+		Type:     race.MsgEnd,
+		At:       m.state().Elapsed,
+		WPM:      result.WPM,
+		Accuracy: result.Accuracy,
+	})
+}
+
+// watchMode shows a broadcast game with the HUD of the mode it is played
+// in. The game ends when the typist's game does, not on its own.
+type watchMode struct {
+	GameMode
+}
+
+func (m *watchMode) IsComplete(state GameState) bool { return false }
+
+// WatchModel replays a broadcast game keystroke by keystroke in a read-only
+// typing view.
+type WatchModel struct {
+	client   *race.Client
+	addr     string
+	game     *TypingModel
+	end      *EndGameModel
+	err      error
+	width    int
+	height   int
+	lastTick time.Time
+}
+
+func NewWatchModel(client *race.Client, addr string) *WatchModel {
+	return &WatchModel{
+		client:   client,
+		addr:     addr,
+		lastTick: time.Now(),
+	}
+}
+
+func (m *WatchModel) Init() tea.Cmd {
+	return tea.Batch(InitGlobalTick(), m.receive())
+}
+
+func (m *WatchModel) receive() tea.Cmd {
+	return func() tea.Msg {
+		msg, err := m.client.Receive()
+		if err != nil {
+			return raceErrMsg{err}
+		}
+		return raceMsg(msg)
+	}
+}
+
+func (m *WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.game != nil {
+			m.game.Update(msg)
+		}
+		if m.end != nil {
+			m.end.Update(msg)
+		}
+		return m, nil
+
+	case GlobalTickMsg:
+		switch {
+		case m.end != nil:
+			_, cmd := m.end.Update(msg)
+			return m, cmd
+		case m.game != nil:
+			_, cmd := m.game.Update(msg)
+			return m, cmd
+		}
+		var cmd tea.Cmd
+		m.lastTick, _, cmd = HandleGlobalTick(m.lastTick, msg)
+		return m, cmd
+
+	case raceMsg:
+		m.replay(race.Message(msg))
+		return m, m.receive()
+
+	case raceErrMsg:
+		devlog.Log("Watch: %v", msg.err)
+		m.err = msg.err
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			return m, tea.Quit
+		}
+		if m.err != nil {
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// replay applies a broadcast message to the local copy of the game.
+func (m *WatchModel) replay(msg race.Message) {
+	switch msg.Type {
+	case race.MsgSession:
+		m.end = nil
+		m.game = NewTypingModel(m.width, m.height, msg.Text)
+		m.game.mode = &watchMode{LookupGameMode(msg.Mode)}
+		m.game.text.SetErrorPolicy(ParseErrorPolicy(msg.ErrorPolicy))
+		m.game.text.SetBackspacePolicy(ParseBackspacePolicy(msg.BackspacePolicy))
+		m.game.announced = true
+		m.game.bots = nil
+		m.game.banner = HelpStyle(fmt.Sprintf("Watching %s live, read-only. Press ESC to stop watching.", m.addr))
+
+	case race.MsgKey:
+		if m.game == nil {
+			return
+		}
+		if !m.game.timerRunning {
+			m.game.timerRunning = true
+			m.game.startTime = time.Now().Add(-msg.At)
+		}
+		switch KeyAction(msg.Action) {
+		case ActionType:
+			m.game.text.TypeRunes([]rune(msg.Text))
+		case ActionBackspace:
+			m.game.text.Backspace()
+		case ActionDeleteWord:
+			m.game.text.DeleteWord()
+		}
+
+	case race.MsgEnd:
+		if m.game == nil {
+			return
+		}
+		state := m.game.state()
+		state.Elapsed = msg.At
+		result := m.game.mode.Score(state)
+		m.end = NewEndGameModel(msg.WPM, msg.Accuracy, result.Words, result.Correct, result.Errors, m.game.text.GetText())
+		m.end.width = m.width
+		m.end.height = m.height
+		m.end.errorPolicy = m.game.text.ErrorPolicy()
+		m.end.backspacePolicy = m.game.text.BackspacePolicy()
+		m.end.extra = m.game.text.ExtraLetters()
+		m.end.watching = true
+	}
+}
+
+func (m *WatchModel) View() string {
+	if m.err != nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			ErrorStyle.Render("The broadcast ended: "+m.err.Error())+"\n\n"+HelpStyle("Press any key to quit"))
+	}
+
+	switch {
+	case m.end != nil:
+		return m.end.View()
+	case m.game != nil:
+		return m.game.View()
+	}
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		HintStyle(fmt.Sprintf("Connected to %s, waiting for the next game...", m.addr))+"\n\n"+HelpStyle("Press ESC to stop watching"))
+}
+
+// RunWatch shows the game broadcast at addr until it ends or the watcher quits.
+func RunWatch(addr string) error {
+	client, err := race.Watch(addr)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if _, err := tea.NewProgram(NewWatchModel(client, addr), tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error watching: %w", err)
+	}
+	return nil
+}
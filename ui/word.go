@@ -8,10 +8,15 @@ import (
 	"time"
 
 	devlog "github.com/prime-run/go-typer/log"
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
 )
 
 type WordState int
 
+// maxExtraLetters caps how many letters can be typed past the end of a word.
+const maxExtraLetters = 10
+
 const (
 	Untyped WordState = iota
 	Perfect
@@ -19,24 +24,31 @@ const (
 	Error
 )
 
+// NOTE: target and typed hold grapheme clusters, not runes, so "é" written
+// as e + U+0301 or a skin-toned emoji count as a single letter.
+// an empty string in typed marks a skipped letter.
 type Word struct {
-	target []rune
-	typed  []rune
+	target []string
+	typed  []string
 	state  WordState
 	active bool
+	rtl    bool
 	cursor *Cursor
 	cached string
 	dirty  bool
+
+	mistakes int   // wrong keys the error policy refused, they never show up in typed
+	carets   []int // letters other racers' carets are on
 }
 
-func NewWord(target []rune) *Word {
+func NewWord(target []string) *Word {
 	targetLen := len(target)
-	targetCopy := make([]rune, targetLen)
+	targetCopy := make([]string, targetLen)
 	copy(targetCopy, target)
 
 	return &Word{
 		target: targetCopy,
-		typed:  make([]rune, 0, targetLen),
+		typed:  make([]string, 0, targetLen),
 		state:  Untyped,
 		active: false,
 		cursor: NewCursor(DefaultCursorType),
@@ -44,52 +56,89 @@ func NewWord(target []rune) *Word {
 	}
 }
 
-func (w *Word) Type(r rune) {
+// Type records one grapheme cluster typed by the player.
+func (w *Word) Type(g string) {
 	if w.IsSpace() {
-		if r == ' ' {
-			w.typed = []rune{' '}
+		if g == " " {
+			w.typed = []string{" "}
 			w.state = Perfect
 		} else {
-			w.typed = []rune{r}
+			w.typed = []string{g}
 			w.state = Error
 		}
 		w.dirty = true
 		return
 	}
 
-	if len(w.typed) < len(w.target) {
-		w.typed = append(w.typed, r)
-	} else if len(w.typed) == len(w.target) {
-		w.typed[len(w.typed)-1] = r
+	// NOTE: letters past the end of the word are kept as extra letters (overtype)
+	if len(w.typed) >= len(w.target)+maxExtraLetters {
+		return
 	}
+	w.typed = append(w.typed, g)
 
 	w.updateState()
 	w.dirty = true
 }
 
+// Extend appends runes that continue the last typed grapheme cluster
+// (combining marks from dead keys, emoji modifiers, ZWJ sequences or the
+// second half of a flag). It returns false when s starts a new cluster.
+func (w *Word) Extend(s string) bool {
+	if w.IsSpace() || len(w.typed) == 0 {
+		return false
+	}
+
+	last := w.typed[len(w.typed)-1]
+	if last == "" {
+		return false
+	}
+
+	joined := norm.NFC.String(last + s)
+	if uniseg.GraphemeClusterCount(joined) != 1 {
+		return false
+	}
+
+	w.typed[len(w.typed)-1] = joined
+	w.updateState()
+	w.dirty = true
+	return true
+}
+
 func (w *Word) Skip() {
 	targetLen := len(w.target)
 	typedLen := len(w.typed)
 
 	if typedLen == 0 {
-		// NOTE:optimize by pre-allocating the full array
-		w.typed = make([]rune, targetLen)
-		for i := 0; i < targetLen; i++ {
-			w.typed[i] = '\x00'
-		}
+		// NOTE:optimize by pre-allocating the full array, "" marks a skipped letter
+		w.typed = make([]string, targetLen)
 	} else if typedLen < targetLen {
 		// NOTE:optimize by growing the slice once
-		needed := targetLen - typedLen
-		w.typed = append(w.typed, make([]rune, needed)...)
-		for i := typedLen; i < targetLen; i++ {
-			w.typed[i] = '\x00'
-		}
+		w.typed = append(w.typed, make([]string, targetLen-typedLen)...)
 	}
 
 	w.state = Error
 	w.dirty = true
 }
 
+// Extra returns how many letters were typed past the end of the word.
+func (w *Word) Extra() int {
+	if w.IsSpace() {
+		return 0
+	}
+	return max(len(w.typed)-len(w.target), 0)
+}
+
+// Mistake records a wrong key that the error policy didn't let through.
+func (w *Word) Mistake() {
+	w.mistakes++
+	w.dirty = true
+}
+
+// Correct reports whether the word was typed right without any refused keys.
+func (w *Word) Correct() bool {
+	return w.state == Perfect && w.mistakes == 0
+}
+
 func (w *Word) Backspace() bool {
 	if len(w.typed) == 0 {
 		return false
@@ -100,6 +149,18 @@ func (w *Word) Backspace() bool {
 	return true
 }
 
+// Clear deletes everything typed into the word. It returns false if
+// nothing was typed.
+func (w *Word) Clear() bool {
+	if len(w.typed) == 0 {
+		return false
+	}
+	w.typed = w.typed[:0]
+	w.updateState()
+	w.dirty = true
+	return true
+}
+
 func (w *Word) updateState() {
 	if len(w.typed) == 0 {
 		w.state = Untyped
@@ -107,7 +168,7 @@ func (w *Word) updateState() {
 	}
 
 	if w.IsSpace() {
-		if len(w.typed) == 1 && w.typed[0] == ' ' {
+		if len(w.typed) == 1 && w.typed[0] == " " {
 			w.state = Perfect
 		} else {
 			w.state = Error
@@ -115,7 +176,7 @@ func (w *Word) updateState() {
 		return
 	}
 
-	if slices.Contains(w.typed, '\x00') {
+	if slices.Contains(w.typed, "") {
 		w.state = Error
 		return
 	}
@@ -141,7 +202,7 @@ func (w *Word) updateState() {
 func (w *Word) IsComplete() bool {
 	complete := len(w.typed) >= len(w.target)
 	devlog.Log("Word: IsComplete - Target: '%s' (%d), Typed: '%s' (%d), Complete: %v",
-		string(w.target), len(w.target), string(w.typed), len(w.typed), complete)
+		w.Target(), len(w.target), strings.Join(w.typed, ""), len(w.typed), complete)
 	return complete
 }
 
@@ -150,7 +211,12 @@ func (w *Word) HasStarted() bool {
 }
 
 func (w *Word) IsSpace() bool {
-	return len(w.target) == 1 && w.target[0] == ' '
+	return len(w.target) == 1 && w.target[0] == " "
+}
+
+// Target returns the word as it should be typed.
+func (w *Word) Target() string {
+	return strings.Join(w.target, "")
 }
 
 func (w *Word) SetActive(active bool) {
@@ -160,6 +226,30 @@ func (w *Word) SetActive(active bool) {
 	}
 }
 
+// SetRTL marks the word to be drawn right to left.
+func (w *Word) SetRTL(rtl bool) {
+	if w.rtl != rtl {
+		w.rtl = rtl
+		w.dirty = true
+	}
+}
+
+// glyph returns how a grapheme is drawn, brackets are mirrored in RTL words.
+func (w *Word) glyph(g string) string {
+	if w.rtl {
+		return mirrorGlyph(g)
+	}
+	return g
+}
+
+// SetCarets marks the letters other racers' carets are on.
+func (w *Word) SetCarets(letters []int) {
+	if !slices.Equal(w.carets, letters) {
+		w.carets = letters
+		w.dirty = true
+	}
+}
+
 func (w *Word) SetCursorType(cursorType CursorType) {
 	w.cursor = NewCursor(cursorType)
 	w.dirty = true
@@ -184,18 +274,22 @@ func (w *Word) Render(showCursor bool) string {
 
 	result.Grow(max(len(w.target), len(w.typed)) * 3)
 	if w.IsSpace() {
+		if len(w.carets) > 0 && !(showCursor && w.active) {
+			w.cached = RacerCaretStyle.Render(" ")
+			return w.cached
+		}
 		if len(w.typed) == 0 {
 			if showCursor && w.active {
-				w.cached = w.cursor.Render(' ')
+				w.cached = w.cursor.Render(" ")
 				return w.cached
 			}
 			w.cached = DimStyle.Render(" ")
 			return w.cached
-		} else if len(w.typed) == 1 && w.typed[0] == ' ' {
+		} else if len(w.typed) == 1 && w.typed[0] == " " {
 			w.cached = InputStyle.Render(" ")
 			return w.cached
 		} else {
-			w.cached = ErrorStyle.Render(string(w.typed[0]))
+			w.cached = ErrorStyle.Render(fitWidth(w.typed[0], " "))
 			return w.cached
 		}
 	}
@@ -203,40 +297,59 @@ func (w *Word) Render(showCursor bool) string {
 	targetLen := len(w.target)
 	typedLen := len(w.typed)
 
+	// NOTE: one styled cell per grapheme, reversed for right to left words so
+	// the caret and errors move in reading direction
+	cells := make([]string, 0, max(targetLen, typedLen)+1)
+
 	for i := 0; i < max(targetLen, typedLen); i++ {
 		if showCursor && w.active && i == typedLen {
 			if i < targetLen {
-				result.WriteString(w.cursor.Render(w.target[i]))
+				cells = append(cells, w.cursor.Render(w.glyph(w.target[i])))
 			} else {
-				result.WriteString(w.cursor.Render(' '))
+				cells = append(cells, w.cursor.Render(" "))
 			}
 			continue
 		}
 
 		if i >= typedLen {
-			result.WriteString(DimStyle.Render(string(w.target[i])))
+			cells = append(cells, DimStyle.Render(w.glyph(w.target[i])))
 			continue
 		}
 
 		if i >= targetLen {
-			result.WriteString(ErrorStyle.Render(string(w.typed[i])))
+			cells = append(cells, ErrorStyle.Render(w.glyph(w.typed[i])))
 			continue
 		}
 
-		if w.typed[i] == '\x00' {
-			result.WriteString(DimStyle.Render(string(w.target[i])))
+		if w.typed[i] == "" {
+			cells = append(cells, DimStyle.Render(w.glyph(w.target[i])))
 			continue
 		}
 
 		if w.typed[i] == w.target[i] {
-			if w.state == Error {
-				result.WriteString(PartialErrorStyle.Render(string(w.target[i])))
+			if w.state == Error || w.mistakes > 0 {
+				cells = append(cells, PartialErrorStyle.Render(w.glyph(w.target[i])))
 			} else {
-				result.WriteString(InputStyle.Render(string(w.target[i])))
+				cells = append(cells, InputStyle.Render(w.glyph(w.target[i])))
 			}
 		} else {
-			result.WriteString(ErrorStyle.Render(string(w.typed[i])))
+			cells = append(cells, ErrorStyle.Render(w.glyph(fitWidth(w.typed[i], w.target[i]))))
+		}
+	}
+
+	// NOTE: other racers' carets are drawn over the letter they're on, our own caret wins
+	for _, i := range w.carets {
+		if i < 0 || i >= len(cells) || i >= targetLen || (showCursor && w.active && i == typedLen) {
+			continue
 		}
+		cells[i] = RacerCaretStyle.Render(w.glyph(w.target[i]))
+	}
+
+	if w.rtl {
+		slices.Reverse(cells)
+	}
+	for _, cell := range cells {
+		result.WriteString(cell)
 	}
 
 	rendered := result.String()
@@ -254,6 +367,28 @@ func (w *Word) Render(showCursor bool) string {
 	return rendered
 }
 
+// fitWidth returns the typed grapheme if it takes as many terminal cells as
+// the target one, otherwise the target is shown so a wrong wide (or narrow)
+// character doesn't shift the rest of the line.
+func fitWidth(typed, target string) string {
+	if uniseg.StringWidth(typed) == uniseg.StringWidth(target) {
+		return typed
+	}
+	return target
+}
+
+// graphemes splits s into user-perceived characters.
+func graphemes(s string) []string {
+	clusters := make([]string, 0, len(s))
+	state := -1
+	for len(s) > 0 {
+		var cluster string
+		cluster, s, _, state = uniseg.FirstGraphemeClusterInString(s, state)
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
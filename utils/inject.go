@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// InjectNumbers returns words with a random number inserted after roughly
+// rate (0-1) of them.
+func InjectNumbers(words []string, rate float64) []string {
+	if rate <= 0 || len(words) == 0 {
+		return words
+	}
+
+	result := make([]string, 0, len(words)+int(float64(len(words))*rate)+1)
+	for _, word := range words {
+		result = append(result, word)
+		if RandomFloat64() < rate {
+			result = append(result, randomNumber())
+		}
+	}
+
+	return result
+}
+
+// randomNumber mixes short numbers, longer ones and years, the way numbers
+// show up in regular prose.
+func randomNumber() string {
+	switch r := RandomFloat64(); {
+	case r < 0.6:
+		return fmt.Sprint(RandomIntn(100))
+	case r < 0.85:
+		return fmt.Sprint(100 + RandomIntn(9900))
+	default:
+		return fmt.Sprint(1900 + RandomIntn(131))
+	}
+}
+
+// InjectPunctuation turns a plain word list into sentences: roughly rate
+// (0-1) of the words get a comma, sentence end, quotes or brackets, sentences
+// start with a capital letter and the text ends with a period.
+func InjectPunctuation(words []string, rate float64) []string {
+	if len(words) == 0 {
+		return words
+	}
+
+	result := make([]string, len(words))
+	capitalize := true
+
+	for i, word := range words {
+		if capitalize {
+			word = capitalizeFirst(word)
+			capitalize = false
+		}
+
+		if i < len(words)-1 && RandomFloat64() < rate {
+			switch r := RandomFloat64(); {
+			case r < 0.4:
+				word += ","
+			case r < 0.6:
+				word += "."
+				capitalize = true
+			case r < 0.7:
+				word += []string{"?", "!"}[RandomIntn(2)]
+				capitalize = true
+			case r < 0.8:
+				word = "\"" + word + "\""
+			case r < 0.9:
+				word = "(" + word + ")"
+			default:
+				word += []string{";", ":"}[RandomIntn(2)]
+			}
+		}
+
+		result[i] = word
+	}
+
+	if last := result[len(result)-1]; !HasPonctuationSuffix(last) {
+		result[len(result)-1] = last + "."
+	}
+
+	return result
+}
+
+// HasPunctuation reports whether text contains any punctuation mark.
+func HasPunctuation(text string) bool {
+	return strings.IndexFunc(text, unicode.IsPunct) >= 0
+}
+
+func capitalizeFirst(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if r == utf8.RuneError {
+		return word
+	}
+	return string(unicode.ToUpper(r)) + word[size:]
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NOTE: only characters that are awkward to type on most layouts are mapped,
+// guillemets and other language specific quotes are left alone on purpose.
+var smartPunctuationReplacer = strings.NewReplacer(
+	"‘", "'", // left single quote
+	"’", "'", // right single quote / apostrophe
+	"‚", "'", // single low-9 quote
+	"‛", "'", // single high-reversed-9 quote
+	"′", "'", // prime
+	"“", "\"", // left double quote
+	"”", "\"", // right double quote
+	"„", "\"", // double low-9 quote
+	"‟", "\"", // double high-reversed-9 quote
+	"″", "\"", // double prime
+	"‐", "-", // hyphen
+	"‑", "-", // non-breaking hyphen
+	"‒", "-", // figure dash
+	"–", "-", // en dash
+	"—", "-", // em dash
+	"―", "-", // horizontal bar
+	"…", "...", // ellipsis
+	"\u00a0", " ", // no-break space
+)
+
+// StripDiacritics removes combining marks after canonical decomposition, so
+// "crème brûlée" becomes "creme brulee". Letters without a decomposition
+// (ß, ø, cyrillic, ...) are left untouched.
+func StripDiacritics(text string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, text)
+	if err != nil {
+		return text
+	}
+	return result
+}
+
+// TruncateRunes cuts text to at most n runes without splitting a character.
+func TruncateRunes(text string, n int) string {
+	if n < 0 {
+		return ""
+	}
+	count := 0
+	for i := range text {
+		if count == n {
+			return text[:i]
+		}
+		count++
+	}
+	return text
+}
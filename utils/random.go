@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Everything random in generated text (quote choice, word sampling, injected
+// numbers and punctuation, drills, snippets) draws from this one source, so
+// seeding it reproduces every passage.
+var (
+	randomMu     sync.Mutex
+	randomSource = rand.New(rand.NewSource(time.Now().UnixNano()))
+	randomSeeded bool
+)
+
+// SeedRandom reseeds the text generator. The same seed gives the same
+// passages, in the same order, on any machine.
+func SeedRandom(seed int64) {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	randomSource = rand.New(rand.NewSource(seed))
+	randomSeeded = true
+}
+
+// RandomSeeded reports whether the text generator runs on a fixed seed.
+func RandomSeeded() bool {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	return randomSeeded
+}
+
+// RandomIntn returns a number in [0, n) from the text generator.
+func RandomIntn(n int) int {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	return randomSource.Intn(n)
+}
+
+// RandomFloat64 returns a number in [0, 1) from the text generator.
+func RandomFloat64() float64 {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+
+	return randomSource.Float64()
+}
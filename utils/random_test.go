@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+// draws takes a few numbers from the text generator.
+func draws() []int {
+	numbers := make([]int, 20)
+	for i := range numbers {
+		numbers[i] = RandomIntn(1000)
+	}
+	return numbers
+}
+
+func TestSeedRandom(t *testing.T) {
+	tests := []struct {
+		a, b     int64
+		wantSame bool
+	}{
+		{1234, 1234, true},
+		{0, 0, true},
+		{-7, -7, true},
+		{0, 1, false},
+		{1234, 1235, false},
+	}
+
+	for _, tt := range tests {
+		SeedRandom(tt.a)
+		a := draws()
+		SeedRandom(tt.b)
+		b := draws()
+
+		if same := slices.Equal(a, b); same != tt.wantSame {
+			t.Errorf("seeds %d and %d: same numbers = %v, want %v", tt.a, tt.b, same, tt.wantSame)
+		}
+	}
+
+	if !RandomSeeded() {
+		t.Error("the generator doesn't report the seed")
+	}
+}
+
+func TestSeededInjection(t *testing.T) {
+	words := []string{"the", "quick", "brown", "fox", "jumps", "over", "the", "lazy", "dog"}
+	inject := func(seed int64) []string {
+		SeedRandom(seed)
+		return InjectPunctuation(InjectNumbers(words, 0.5), 0.5)
+	}
+
+	if a, b := inject(42), inject(42); !slices.Equal(a, b) {
+		t.Errorf("the same seed injected %q and %q", a, b)
+	}
+}
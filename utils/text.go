@@ -3,27 +3,15 @@ package utils
 import (
 	"fmt"
 	"strings"
-	"unicode"
 
 	"github.com/prime-run/go-typer/types"
 )
 
-func FormatText(text string) string {
-	text = sanitizeText(text)
-	return text
-}
-
-func sanitizeText(text string) string {
-	text = strings.Map(func(r rune) rune {
-		if !unicode.IsPrint(r) {
-			return -1
-		}
-		return r
-	}, strings.TrimSpace(text))
+// formatPipeline only cleans up text, it keeps case, punctuation and symbols.
+var formatPipeline, _ = ParsePipeline([]string{"nfc", "strip_control", "collapse_whitespace"})
 
-	text = strings.Join(strings.Fields(text), " ")
-
-	return text
+func FormatText(text string) string {
+	return formatPipeline.Apply(text)
 }
 
 func PrintTextStats(mode types.Mode, formatedTxt string) {
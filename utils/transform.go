@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// TextTransform rewrites a passage. Transforms are chained into a Pipeline.
+type TextTransform func(text string) string
+
+// transformFactory builds a transform from the argument after the colon in
+// its spec ("max_words:50"), arg is empty when there is none.
+type transformFactory func(arg string) (TextTransform, error)
+
+const (
+	defaultNumberRate      = 0.1
+	defaultPunctuationRate = 0.15
+)
+
+var transforms = map[string]transformFactory{
+	// nfc composes characters so "e" + combining accent matches a typed "é"
+	"nfc": noArg(norm.NFC.String),
+	// ascii_quotes maps typographic quotes, dashes and ellipses to plain ASCII
+	"ascii_quotes": noArg(smartPunctuationReplacer.Replace),
+	// ascii_fold folds accented letters to their base letter (é -> e)
+	"ascii_fold": noArg(StripDiacritics),
+	"lowercase":  noArg(strings.ToLower),
+	// strip_control drops characters that don't print at all
+	"strip_control": noArg(func(text string) string {
+		return strings.Map(func(r rune) rune {
+			if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, text)
+	}),
+	// strip_untypeable replaces symbols, emoji and control characters with a
+	// space, letters of any script, marks, digits and punctuation are kept
+	"strip_untypeable": noArg(func(text string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r) || unicode.IsPunct(r) {
+				return r
+			}
+			return ' '
+		}, text)
+	}),
+	"strip_punctuation": noArg(func(text string) string {
+		return strings.Map(func(r rune) rune {
+			switch {
+			case unicode.Is(unicode.Pd, r):
+				// NOTE: dashes separate words ("well-known"), so they become a space
+				return ' '
+			case unicode.IsPunct(r):
+				return -1
+			}
+			return r
+		}, text)
+	}),
+	"collapse_whitespace": noArg(func(text string) string {
+		return strings.Join(strings.Fields(text), " ")
+	}),
+	"max_words": func(arg string) (TextTransform, error) {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("max_words needs a positive word count, got %q", arg)
+		}
+		return func(text string) string {
+			words := strings.Fields(text)
+			if len(words) <= n {
+				return text
+			}
+			return strings.Join(words[:n], " ")
+		}, nil
+	},
+	"max_chars": func(arg string) (TextTransform, error) {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("max_chars needs a positive character count, got %q", arg)
+		}
+		return func(text string) string { return TruncateRunes(text, n) }, nil
+	},
+	"inject_numbers": func(arg string) (TextTransform, error) {
+		rate, err := parseRate(arg, defaultNumberRate)
+		if err != nil {
+			return nil, fmt.Errorf("inject_numbers: %w", err)
+		}
+		return wordsTransform(func(words []string) []string { return InjectNumbers(words, rate) }), nil
+	},
+	// NOTE: punctuation is only added to plain word lists, quotes keep their own
+	"inject_punctuation": func(arg string) (TextTransform, error) {
+		rate, err := parseRate(arg, defaultPunctuationRate)
+		if err != nil {
+			return nil, fmt.Errorf("inject_punctuation: %w", err)
+		}
+		return func(text string) string {
+			if HasPunctuation(text) {
+				return text
+			}
+			return strings.Join(InjectPunctuation(strings.Fields(text), rate), " ")
+		}, nil
+	},
+}
+
+func noArg(fn func(string) string) transformFactory {
+	return func(arg string) (TextTransform, error) {
+		if arg != "" {
+			return nil, fmt.Errorf("takes no argument, got %q", arg)
+		}
+		return fn, nil
+	}
+}
+
+func wordsTransform(fn func([]string) []string) TextTransform {
+	return func(text string) string {
+		return strings.Join(fn(strings.Fields(text)), " ")
+	}
+}
+
+func parseRate(arg string, fallback float64) (float64, error) {
+	if arg == "" {
+		return fallback, nil
+	}
+	rate, err := strconv.ParseFloat(arg, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate should be between 0 and 1, got %q", arg)
+	}
+	return rate, nil
+}
+
+// Pipeline is a chain of text transforms applied in order.
+type Pipeline []TextTransform
+
+// ParsePipeline builds a pipeline from transform specs such as "lowercase"
+// or "max_words:100".
+func ParsePipeline(specs []string) (Pipeline, error) {
+	pipeline := make(Pipeline, 0, len(specs))
+	for _, spec := range specs {
+		name, arg, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		factory, ok := transforms[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown text transform %q (available: %s)", name, strings.Join(ListTransforms(), ", "))
+		}
+		transform, err := factory(arg)
+		if err != nil {
+			return nil, fmt.Errorf("text transform %s: %w", name, err)
+		}
+		pipeline = append(pipeline, transform)
+	}
+	return pipeline, nil
+}
+
+// Apply runs text through every transform of the pipeline.
+func (p Pipeline) Apply(text string) string {
+	for _, transform := range p {
+		text = transform(text)
+	}
+	return text
+}
+
+// ListTransforms returns the names of all available transforms.
+func ListTransforms() []string {
+	names := make([]string, 0, len(transforms))
+	for name := range transforms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
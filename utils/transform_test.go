@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePipeline(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   []string
+		wantErr string // part of the error, empty for none
+	}{
+		{"empty", nil, ""},
+		{"plain transforms", []string{"nfc", "lowercase", "collapse_whitespace"}, ""},
+		{"arguments", []string{"max_words:10", "max_chars:200", "inject_numbers:0.5", "inject_punctuation"}, ""},
+		{"spaces around a spec", []string{" lowercase "}, ""},
+		{"unknown transform", []string{"lowercase", "shout"}, `unknown text transform "shout"`},
+		{"argument for a plain transform", []string{"lowercase:1"}, "takes no argument"},
+		{"zero words", []string{"max_words:0"}, "positive word count"},
+		{"words not a number", []string{"max_words:ten"}, "positive word count"},
+		{"negative chars", []string{"max_chars:-1"}, "positive character count"},
+		{"rate above one", []string{"inject_numbers:2"}, "between 0 and 1"},
+		{"rate not a number", []string{"inject_punctuation:lots"}, "between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := ParsePipeline(tt.specs)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Fatalf("got no error, want one containing %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Fatalf("error %q, want it to contain %q", err, tt.wantErr)
+			case tt.wantErr == "" && len(pipeline) != len(tt.specs):
+				t.Errorf("got %d transforms, want %d", len(pipeline), len(tt.specs))
+			}
+		})
+	}
+}
+
+func TestPipelineApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs []string
+		text  string
+		want  string
+	}{
+		{"nothing", nil, "As is.", "As is."},
+		{"nfc", []string{"nfc"}, "cafe\u0301", "café"},
+		{"ascii quotes", []string{"ascii_quotes"}, "“It’s” – fine…", "\"It's\" - fine..."},
+		{"ascii quotes keep guillemets", []string{"ascii_quotes"}, "«oui»", "«oui»"},
+		{"ascii fold", []string{"ascii_fold"}, "Crème brûlée", "Creme brulee"},
+		{"ascii fold keeps other letters", []string{"ascii_fold"}, "Straße øre", "Straße øre"},
+		{"lowercase", []string{"lowercase"}, "Go Typer", "go typer"},
+		{"strip control", []string{"strip_control"}, "a\x00b\tc\u200bd", "ab\tcd"},
+		{"strip untypeable", []string{"strip_untypeable"}, "hi 👋 you", "hi   you"},
+		{"strip punctuation", []string{"strip_punctuation"}, "well-known, right?", "well known right"},
+		{"collapse whitespace", []string{"collapse_whitespace"}, "  a \n\t b  ", "a b"},
+		{"max words", []string{"max_words:2"}, "one two three", "one two"},
+		{"max words short text", []string{"max_words:5"}, "one  two", "one  two"},
+		{"max chars", []string{"max_chars:3"}, "héllo", "hél"},
+		{"no numbers at rate 0", []string{"inject_numbers:0"}, "a b c", "a b c"},
+		{"quotes keep their punctuation", []string{"inject_punctuation:1"}, "Hello, you", "Hello, you"},
+		{"punctuation at rate 0", []string{"inject_punctuation:0"}, "a b c", "A b c."},
+		{"in order", []string{"lowercase", "strip_punctuation", "collapse_whitespace", "max_words:2"}, "Hello, World! Again", "hello world"},
+		{"order matters", []string{"max_words:2", "lowercase"}, "Hello, World! Again", "hello, world!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline, err := ParsePipeline(tt.specs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := pipeline.Apply(tt.text); got != tt.want {
+				t.Errorf("Apply(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"  Hello,\n\tWorld!  ", "Hello, World!"},
+		{"cafe\u0301 \x07bell", "café bell"},
+		{"“Smart” quotes stay", "“Smart” quotes stay"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatText(tt.text); got != tt.want {
+			t.Errorf("FormatText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}